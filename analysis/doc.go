@@ -0,0 +1,7 @@
+// Package analysis combines the access providers of Raito Cloud with data usage to find access that is not used.
+//
+// The SDK has no usage client: DataUsage and QueryHistoryStatement only exist as typename-only members of the
+// paging unions, and the queries expose no usage or query history operations. The usage is therefore provided
+// by the caller through a UsageSource, e.g. from the query history of the data warehouse.
+// UsageSource can be backed by a usage client once the schema exposes data usage.
+package analysis
@@ -0,0 +1,254 @@
+package analysis
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/aws/smithy-go/ptr"
+
+	"github.com/raito-io/sdk-go/internal"
+	"github.com/raito-io/sdk-go/services"
+	"github.com/raito-io/sdk-go/types"
+	"github.com/raito-io/sdk-go/types/models"
+)
+
+// UnusedAccessFinding is a principal that did not use a data object it has access to within the window.
+type UnusedAccessFinding struct {
+	AccessProviderId   string           `json:"accessProviderId"`
+	AccessProviderName string           `json:"accessProviderName"`
+	Principal          Principal        `json:"principal"`
+	DataObject         types.DataObject `json:"dataObject"`
+	LastUsed           *time.Time       `json:"lastUsed,omitempty"`
+}
+
+// UnusedAccessReport is the result of UnusedAccess.
+type UnusedAccessReport struct {
+	Since    time.Time             `json:"since"`
+	Findings []UnusedAccessFinding `json:"findings"`
+
+	// DataObjectCounts holds the number of data objects in the what list of each analysed access provider.
+	// It is used by RemediationPlan to decide whether a principal used none of the data objects of an access provider.
+	DataObjectCounts map[string]int `json:"dataObjectCounts"`
+}
+
+// WhoItemRemoval lists the who items to remove from a single access provider.
+type WhoItemRemoval struct {
+	AccessProviderId   string      `json:"accessProviderId"`
+	AccessProviderName string      `json:"accessProviderName"`
+	Principals         []Principal `json:"principals"`
+}
+
+// RemediationPlan lists the who items to remove to revoke unused access.
+type RemediationPlan struct {
+	Removals []WhoItemRemoval `json:"removals"`
+}
+
+// UnusedAccess returns, for every user and group who item of the access providers matching filter, the data objects in the what list that were not used within window.
+// If filter is nil, only active grant and purpose access providers are analysed.
+// Promise who items are ignored as they do not grant access. Access providers in the who or what list (inheritance) are not expanded.
+// Access providers created within the window are skipped, as their who items cannot have been unused for the whole window.
+// The usage is provided by usage, as the Raito API does not expose data usage.
+func UnusedAccess(ctx context.Context, accessProviderClient *services.AccessProviderClient, usage UsageSource, window time.Duration, filter *types.AccessProviderFilterInput) (*UnusedAccessReport, error) {
+	if window <= 0 {
+		return nil, types.NewErrInvalidInput("window must be greater than 0")
+	}
+
+	if filter == nil {
+		filter = &types.AccessProviderFilterInput{
+			States:  []models.AccessProviderState{models.AccessProviderStateActive},
+			Actions: []models.AccessProviderAction{models.AccessProviderActionGrant, models.AccessProviderActionPurpose},
+		}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	accessProviders, err := internal.CollectListItems(accessProviderClient.ListAccessProviders(ctx, services.WithAccessProviderListFilter(filter)))
+	if err != nil {
+		return nil, fmt.Errorf("list access providers: %w", err)
+	}
+
+	sort.Slice(accessProviders, func(i, j int) bool {
+		return accessProviders[i].Id < accessProviders[j].Id
+	})
+
+	report := UnusedAccessReport{
+		Since:            time.Now().Add(-window),
+		DataObjectCounts: make(map[string]int),
+	}
+
+	for i := range accessProviders {
+		ap := &accessProviders[i]
+
+		if ap.CreatedAt.After(report.Since) {
+			continue
+		}
+
+		findings, dataObjectCount, apErr := unusedAccessOfAccessProvider(ctx, accessProviderClient, usage, ap, report.Since)
+		if apErr != nil {
+			return nil, fmt.Errorf("analyse access provider %q: %w", ap.Id, apErr)
+		}
+
+		report.DataObjectCounts[ap.Id] = dataObjectCount
+		report.Findings = append(report.Findings, findings...)
+	}
+
+	return &report, nil
+}
+
+func unusedAccessOfAccessProvider(ctx context.Context, accessProviderClient *services.AccessProviderClient, usage UsageSource, ap *types.AccessProvider, since time.Time) ([]UnusedAccessFinding, int, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	whatItems, err := internal.CollectListItems(accessProviderClient.GetAccessProviderWhatDataObjectList(ctx, ap.Id))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	dataObjects := make(map[string]types.DataObject, len(whatItems))
+	dataObjectIds := make([]string, 0, len(whatItems))
+
+	for i := range whatItems {
+		if whatItems[i].DataObject == nil {
+			continue
+		}
+
+		if _, found := dataObjects[whatItems[i].DataObject.Id]; !found {
+			dataObjects[whatItems[i].DataObject.Id] = whatItems[i].DataObject.DataObject
+			dataObjectIds = append(dataObjectIds, whatItems[i].DataObject.Id)
+		}
+	}
+
+	if len(dataObjectIds) == 0 {
+		return nil, 0, nil
+	}
+
+	whoItems, err := internal.CollectListItems(accessProviderClient.GetAccessProviderWhoList(ctx, ap.Id))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var findings []UnusedAccessFinding
+
+	for i := range whoItems {
+		if whoItems[i].Type == types.AccessWhoItemTypeWhopromise {
+			continue
+		}
+
+		var principal Principal
+
+		switch item := whoItems[i].Item.(type) {
+		case *types.AccessProviderWhoListItemItemUser:
+			principal = Principal{Type: PrincipalTypeUser, Id: item.Id, Name: item.Name}
+		case *types.AccessProviderWhoListItemItemGroup:
+			principal = Principal{Type: PrincipalTypeGroup, Id: item.Id, Name: item.Name}
+		default:
+			continue
+		}
+
+		lastUsage, usageErr := usage.LastUsage(ctx, principal, dataObjectIds)
+		if usageErr != nil {
+			return nil, 0, fmt.Errorf("load usage of %s %q: %w", principal.Type, principal.Id, usageErr)
+		}
+
+		for _, dataObjectId := range dataObjectIds {
+			lastUsed, used := lastUsage[dataObjectId]
+			if used && !lastUsed.Before(since) {
+				continue
+			}
+
+			finding := UnusedAccessFinding{
+				AccessProviderId:   ap.Id,
+				AccessProviderName: ap.Name,
+				Principal:          principal,
+				DataObject:         dataObjects[dataObjectId],
+			}
+
+			if used {
+				finding.LastUsed = &lastUsed
+			}
+
+			findings = append(findings, finding)
+		}
+	}
+
+	return findings, len(dataObjectIds), nil
+}
+
+// RemediationPlan returns the who items that can be removed without revoking access that was used within the window.
+// As a who item grants access to all data objects of an access provider, a principal is only removed if it did not use any of them.
+func (r *UnusedAccessReport) RemediationPlan() *RemediationPlan {
+	type principalKey struct {
+		accessProviderId string
+		principal        Principal
+	}
+
+	unusedCounts := make(map[principalKey]int)
+
+	var keys []principalKey
+	accessProviderNames := make(map[string]string)
+
+	for i := range r.Findings {
+		key := principalKey{accessProviderId: r.Findings[i].AccessProviderId, principal: r.Findings[i].Principal}
+
+		if _, found := unusedCounts[key]; !found {
+			keys = append(keys, key)
+		}
+
+		unusedCounts[key]++
+		accessProviderNames[key.accessProviderId] = r.Findings[i].AccessProviderName
+	}
+
+	plan := RemediationPlan{}
+	removalIdx := make(map[string]int)
+
+	for _, key := range keys {
+		// Without the data object count of the access provider (e.g. a report built without DataObjectCounts), nothing is removed.
+		if count := r.DataObjectCounts[key.accessProviderId]; count == 0 || unusedCounts[key] < count {
+			continue
+		}
+
+		idx, found := removalIdx[key.accessProviderId]
+		if !found {
+			idx = len(plan.Removals)
+			removalIdx[key.accessProviderId] = idx
+
+			plan.Removals = append(plan.Removals, WhoItemRemoval{
+				AccessProviderId:   key.accessProviderId,
+				AccessProviderName: accessProviderNames[key.accessProviderId],
+			})
+		}
+
+		plan.Removals[idx].Principals = append(plan.Removals[idx].Principals, key.principal)
+	}
+
+	return &plan
+}
+
+// Apply removes the who items of the plan through AccessProviderClient.RemoveWhoItems.
+// The removal stops at the first access provider that cannot be updated. Access providers that were already updated are not restored.
+func (p *RemediationPlan) Apply(ctx context.Context, accessProviderClient *services.AccessProviderClient, ops ...func(options *services.UpdateAccessProviderOptions)) error {
+	for _, removal := range p.Removals {
+		items := make([]types.WhoItemInput, 0, len(removal.Principals))
+
+		for _, principal := range removal.Principals {
+			switch principal.Type {
+			case PrincipalTypeUser:
+				items = append(items, types.WhoItemInput{User: ptr.String(principal.Id)})
+			case PrincipalTypeGroup:
+				items = append(items, types.WhoItemInput{Group: ptr.String(principal.Id)})
+			default:
+				return types.NewErrInvalidInput(fmt.Sprintf("unsupported principal type %q", principal.Type))
+			}
+		}
+
+		_, err := accessProviderClient.RemoveWhoItems(ctx, removal.AccessProviderId, items, ops...)
+		if err != nil {
+			return fmt.Errorf("remove unused who items from access provider %q: %w", removal.AccessProviderId, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,175 @@
+package analysis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/raito-io/sdk-go/services"
+	"github.com/raito-io/sdk-go/types"
+)
+
+// fakeGraphqlClient is a graphql.Client that answers requests with the JSON returned by the handler registered for the operation.
+type fakeGraphqlClient struct {
+	mutex    sync.Mutex
+	handlers map[string]func(variables map[string]any) string
+	requests []string
+}
+
+func (c *fakeGraphqlClient) MakeRequest(_ context.Context, req *graphql.Request, resp *graphql.Response) error {
+	data, err := json.Marshal(req.Variables)
+	if err != nil {
+		return err
+	}
+
+	variables := make(map[string]any)
+
+	err = json.Unmarshal(data, &variables)
+	if err != nil {
+		return err
+	}
+
+	c.mutex.Lock()
+	c.requests = append(c.requests, fmt.Sprintf("%s:%v", req.OpName, variables["id"]))
+	handler, found := c.handlers[req.OpName]
+	c.mutex.Unlock()
+
+	if !found {
+		return fmt.Errorf("unexpected operation %q", req.OpName)
+	}
+
+	return json.Unmarshal([]byte(handler(variables)), resp.Data)
+}
+
+// pagedResult returns a single page PagedResult with the given nodes.
+func pagedResult(nodes ...string) string {
+	edges := make([]string, 0, len(nodes))
+	for i, node := range nodes {
+		edges = append(edges, fmt.Sprintf(`{"cursor":"%d","node":%s}`, i, node))
+	}
+
+	return fmt.Sprintf(`{"__typename":"PagedResult","pageInfo":{"hasNextPage":false},"edges":[%s]}`, strings.Join(edges, ","))
+}
+
+func TestUnusedAccess(t *testing.T) {
+	now := time.Now()
+	createdAt := func(age time.Duration) string {
+		return now.Add(-age).UTC().Format(time.RFC3339)
+	}
+
+	client := &fakeGraphqlClient{handlers: map[string]func(variables map[string]any) string{
+		"ListAccessProviders": func(map[string]any) string {
+			return `{"accessProviders":` + pagedResult(
+				`{"__typename":"AccessProvider","id":"ap2","name":"New","createdAt":"`+createdAt(5*24*time.Hour)+`"}`,
+				`{"__typename":"AccessProvider","id":"ap1","name":"Old","createdAt":"`+createdAt(100*24*time.Hour)+`"}`,
+			) + `}`
+		},
+		"GetAccessProviderWhatDataObjectList": func(map[string]any) string {
+			return `{"accessProvider":{"__typename":"AccessProvider","whatDataObjects":` + pagedResult(
+				`{"__typename":"AccessWhatItem","dataObject":{"id":"do1","name":"table1"}}`,
+				`{"__typename":"AccessWhatItem","dataObject":{"id":"do2","name":"table2"}}`,
+			) + `}}`
+		},
+		"GetAccessProviderWhoList": func(map[string]any) string {
+			return `{"accessProvider":{"__typename":"AccessProvider","whoList":` + pagedResult(
+				`{"__typename":"AccessWhoItem","type":"WhoGrant","item":{"__typename":"User","id":"alice","name":"Alice"}}`,
+				`{"__typename":"AccessWhoItem","type":"WhoGrant","item":{"__typename":"Group","id":"analysts","name":"Analysts"}}`,
+				`{"__typename":"AccessWhoItem","type":"WhoPromise","item":{"__typename":"User","id":"bob","name":"Bob"}}`,
+			) + `}}`
+		},
+	}}
+
+	recentUsage := now.Add(-10 * 24 * time.Hour)
+	oldUsage := now.Add(-60 * 24 * time.Hour)
+
+	var usageRequests []Principal
+
+	usage := UsageSourceFunc(func(_ context.Context, principal Principal, dataObjectIds []string) (map[string]time.Time, error) {
+		usageRequests = append(usageRequests, principal)
+
+		assert.Equal(t, []string{"do1", "do2"}, dataObjectIds)
+
+		if principal.Id == "alice" {
+			return map[string]time.Time{"do1": recentUsage, "do2": oldUsage}, nil
+		}
+
+		return nil, nil
+	})
+
+	accessProviderClient := services.NewAccessProviderClient(client)
+
+	report, err := UnusedAccess(context.Background(), &accessProviderClient, usage, 30*24*time.Hour, nil)
+	require.NoError(t, err)
+
+	alice := Principal{Type: PrincipalTypeUser, Id: "alice", Name: "Alice"}
+	analysts := Principal{Type: PrincipalTypeGroup, Id: "analysts", Name: "Analysts"}
+
+	type findingSummary struct {
+		principal    Principal
+		dataObjectId string
+		lastUsed     *time.Time
+	}
+
+	summaries := make([]findingSummary, 0, len(report.Findings))
+
+	for _, finding := range report.Findings {
+		assert.Equal(t, "ap1", finding.AccessProviderId)
+
+		summaries = append(summaries, findingSummary{principal: finding.Principal, dataObjectId: finding.DataObject.Id, lastUsed: finding.LastUsed})
+	}
+
+	assert.Equal(t, []findingSummary{
+		{principal: alice, dataObjectId: "do2", lastUsed: &oldUsage},
+		{principal: analysts, dataObjectId: "do1"},
+		{principal: analysts, dataObjectId: "do2"},
+	}, summaries)
+
+	// Promise who items are not analysed and the recently created ap2 is skipped.
+	assert.Equal(t, []Principal{alice, analysts}, usageRequests)
+	assert.NotContains(t, client.requests, "GetAccessProviderWhoList:ap2")
+	assert.NotContains(t, client.requests, "GetAccessProviderWhatDataObjectList:ap2")
+	assert.Equal(t, map[string]int{"ap1": 2}, report.DataObjectCounts)
+}
+
+func TestUnusedAccessReport_RemediationPlan(t *testing.T) {
+	alice := Principal{Type: PrincipalTypeUser, Id: "alice", Name: "Alice"}
+	bob := Principal{Type: PrincipalTypeUser, Id: "bob", Name: "Bob"}
+	analysts := Principal{Type: PrincipalTypeGroup, Id: "analysts", Name: "Analysts"}
+
+	finding := func(apId string, principal Principal, dataObjectId string) UnusedAccessFinding {
+		return UnusedAccessFinding{
+			AccessProviderId:   apId,
+			AccessProviderName: "AP " + apId,
+			Principal:          principal,
+			DataObject:         types.DataObject{Id: dataObjectId},
+		}
+	}
+
+	report := UnusedAccessReport{
+		Findings: []UnusedAccessFinding{
+			finding("ap1", alice, "do1"),
+			finding("ap1", alice, "do2"),
+			finding("ap1", bob, "do1"),
+			finding("ap1", analysts, "do1"),
+			finding("ap1", analysts, "do2"),
+			finding("ap2", bob, "do3"),
+			finding("ap3", alice, "do4"),
+		},
+		DataObjectCounts: map[string]int{"ap1": 2, "ap2": 1},
+	}
+
+	plan := report.RemediationPlan()
+
+	assert.Equal(t, []WhoItemRemoval{
+		{AccessProviderId: "ap1", AccessProviderName: "AP ap1", Principals: []Principal{alice, analysts}},
+		{AccessProviderId: "ap2", AccessProviderName: "AP ap2", Principals: []Principal{bob}},
+	}, plan.Removals)
+}
@@ -0,0 +1,36 @@
+package analysis
+
+import (
+	"context"
+	"time"
+)
+
+type PrincipalType string
+
+const (
+	PrincipalTypeUser  PrincipalType = "User"
+	PrincipalTypeGroup PrincipalType = "Group"
+)
+
+// Principal is a user or group that is a who item of an access provider.
+type Principal struct {
+	Type PrincipalType `json:"type"`
+	Id   string        `json:"id"`
+	Name string        `json:"name"`
+}
+
+// UsageSource provides the data usage of principals.
+// The Raito API does not expose data usage, so the usage must be provided by the caller, e.g. from the query history of the data warehouse.
+type UsageSource interface {
+	// LastUsage returns, per data object id, the last time the principal used the data object or one of its descendants.
+	// For groups, the last usage by any member of the group is expected.
+	// Data objects that were never used can be omitted from the result.
+	LastUsage(ctx context.Context, principal Principal, dataObjectIds []string) (map[string]time.Time, error)
+}
+
+// UsageSourceFunc is an adapter to use an ordinary function as UsageSource.
+type UsageSourceFunc func(ctx context.Context, principal Principal, dataObjectIds []string) (map[string]time.Time, error)
+
+func (f UsageSourceFunc) LastUsage(ctx context.Context, principal Principal, dataObjectIds []string) (map[string]time.Time, error) {
+	return f(ctx, principal, dataObjectIds)
+}
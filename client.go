@@ -13,6 +13,7 @@ import (
 type RaitoClient struct {
 	accessProviderClient services.AccessProviderClient
 	dataObjectClient     services.DataObjectClient
+	dataShareClient      services.DataShareClient
 	dataSourceClient     services.DataSourceClient
 	grantCategoryClient  services.GrantCategoryClient
 	groupClient          services.GroupClient
@@ -59,6 +60,7 @@ func NewClient(ctx context.Context, domain, user, secret string, ops ...func(opt
 	return &RaitoClient{
 		accessProviderClient: services.NewAccessProviderClient(client),
 		dataObjectClient:     services.NewDataObjectClient(client),
+		dataShareClient:      services.NewDataShareClient(client),
 		dataSourceClient:     services.NewDataSourceClient(client),
 		grantCategoryClient:  services.NewGrantCategoryClient(client),
 		groupClient:          services.NewGroupClient(client),
@@ -78,6 +80,11 @@ func (c *RaitoClient) DataObject() *services.DataObjectClient {
 	return &c.dataObjectClient
 }
 
+// DataShare returns the DataShareClient
+func (c *RaitoClient) DataShare() *services.DataShareClient {
+	return &c.dataShareClient
+}
+
 // DataSource returns the DataSourceClient
 func (c *RaitoClient) DataSource() *services.DataSourceClient {
 	return &c.dataSourceClient
@@ -4,3 +4,7 @@ const DefaultApiEndpoint = "https://api.raito.cloud/"
 const GqlApiPath = "query"
 
 const MaxPageSize = 25
+
+const MaxPatchAttempts = 3
+
+const MaxConcurrentRequests = 8
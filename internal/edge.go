@@ -0,0 +1,31 @@
+package internal
+
+import (
+	"fmt"
+
+	"github.com/raito-io/sdk-go/types"
+)
+
+// DecodeEdgeNode returns the item of the node of a paginated edge.
+// The node is expected to be of type W, from which itemFn extracts the item.
+// A missing node results in a nil item. A node of any other type results in a *types.ErrUnexpectedNodeType.
+func DecodeEdgeNode[N any, W any, T any](cursor *string, node *N, itemFn func(W) *T) (*string, *T, error) {
+	if node == nil || any(*node) == nil {
+		return cursor, nil, nil
+	}
+
+	listItem, ok := any(*node).(W)
+	if !ok {
+		var typename *string
+
+		if typed, hasTypename := any(*node).(interface{ GetTypename() *string }); hasTypename {
+			typename = typed.GetTypename()
+		}
+
+		var expected W
+
+		return cursor, nil, types.NewErrUnexpectedNodeType(typename, fmt.Sprintf("%T", *node), fmt.Sprintf("%T", expected))
+	}
+
+	return cursor, itemFn(listItem), nil
+}
@@ -0,0 +1,107 @@
+package internal
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/raito-io/sdk-go/internal/schema"
+	"github.com/raito-io/sdk-go/types"
+)
+
+func roleAssignmentItem(node *schema.RoleAssignmentPageEdgesEdgeNodeRoleAssignment) *schema.RoleAssignment {
+	return &node.RoleAssignment
+}
+
+func TestDecodeEdgeNode(t *testing.T) {
+	cursor := "cursor"
+
+	var nilNode schema.RoleAssignmentPageEdgesEdgeNodeItem
+
+	tests := []struct {
+		name         string
+		node         *schema.RoleAssignmentPageEdgesEdgeNodeItem
+		expectedItem *schema.RoleAssignment
+		expectedErr  bool
+	}{
+		{
+			name: "no node",
+			node: nil,
+		},
+		{
+			name: "nil node",
+			node: &nilNode,
+		},
+		{
+			name:         "expected node type",
+			node:         nodePtr[schema.RoleAssignmentPageEdgesEdgeNodeItem](&schema.RoleAssignmentPageEdgesEdgeNodeRoleAssignment{RoleAssignment: schema.RoleAssignment{Id: "ra1"}}),
+			expectedItem: &schema.RoleAssignment{Id: "ra1"},
+		},
+		{
+			name:        "unexpected node type",
+			node:        nodePtr[schema.RoleAssignmentPageEdgesEdgeNodeItem](&schema.RoleAssignmentPageEdgesEdgeNodeUser{Typename: stringPtr("User")}),
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resultCursor, item, err := DecodeEdgeNode(&cursor, tt.node, roleAssignmentItem)
+
+			assert.Equal(t, &cursor, resultCursor)
+			assert.Equal(t, tt.expectedItem, item)
+
+			if tt.expectedErr {
+				var nodeErr *types.ErrUnexpectedNodeType
+
+				require.ErrorAs(t, err, &nodeErr)
+				assert.True(t, errors.Is(err, types.ErrUnknownType))
+				assert.Equal(t, stringPtr("User"), nodeErr.Typename)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func FuzzDecodeEdgeNode(f *testing.F) {
+	for _, typename := range []string{"RoleAssignment", "User", "Group", "AccessProvider", "DataObject", "Tag", "", "UnknownType"} {
+		f.Add(typename)
+	}
+
+	f.Fuzz(func(t *testing.T, typename string) {
+		data, err := json.Marshal(map[string]any{"cursor": "cursor", "node": map[string]any{"__typename": typename}})
+		require.NoError(t, err)
+
+		var edge schema.RoleAssignmentPageEdgesEdge
+
+		err = json.Unmarshal(data, &edge)
+		if err != nil {
+			// Unknown type names are rejected while unmarshalling the response
+			return
+		}
+
+		cursor, item, err := DecodeEdgeNode(edge.Cursor, edge.Node, roleAssignmentItem)
+
+		assert.Equal(t, "cursor", *cursor)
+
+		if typename == "RoleAssignment" {
+			assert.NoError(t, err)
+			assert.NotNil(t, item)
+		} else {
+			assert.ErrorIs(t, err, types.ErrUnknownType)
+			assert.Nil(t, item)
+		}
+	})
+}
+
+func nodePtr[N any](node N) *N {
+	return &node
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
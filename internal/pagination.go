@@ -57,6 +57,23 @@ func PaginationExecutor[T any, E any](ctx context.Context, loadPageFn func(ctx c
 	return outputChannel
 }
 
+// CollectListItems reads all items of a list channel.
+// The first error received on the channel is returned.
+// The caller should cancel the context of the list to stop it when an error is returned.
+func CollectListItems[T any](ch <-chan types.ListItem[T]) ([]T, error) {
+	var result []T
+
+	for item := range ch {
+		if item.HasError() {
+			return nil, item.GetError()
+		}
+
+		result = append(result, *item.GetItem())
+	}
+
+	return result, nil
+}
+
 func putOnChannel[T any](ctx context.Context, item T, outputChannel chan<- T) bool {
 	select {
 	case <-ctx.Done():
@@ -167,6 +167,28 @@ func testPaginationExecutorCancel(t *testing.T) {
 
 }
 
+func TestCollectListItems(t *testing.T) {
+	items := make(chan types.ListItem[string], 2)
+	items <- types.NewListItemItem(stringPtr("a"))
+	items <- types.NewListItemItem(stringPtr("b"))
+	close(items)
+
+	result, err := CollectListItems(items)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, result)
+
+	expectedErr := errors.New("list error")
+
+	itemsWithError := make(chan types.ListItem[string], 2)
+	itemsWithError <- types.NewListItemItem(stringPtr("a"))
+	itemsWithError <- types.NewListItemError[string](expectedErr)
+	close(itemsWithError)
+
+	result, err = CollectListItems(itemsWithError)
+	assert.ErrorIs(t, err, expectedErr)
+	assert.Nil(t, result)
+}
+
 // Utility function to get a pointer to bool
 func boolPtr(b bool) *bool {
 	return &b
@@ -8541,11 +8541,19 @@ func (v *AccessProviderWhoListItemItemDataShareRecipient) GetTypename() *string
 // AccessProviderWhoListItemItemDataSource includes the requested fields of the GraphQL type DataSource.
 type AccessProviderWhoListItemItemDataSource struct {
 	Typename *string `json:"__typename"`
+	Id       string  `json:"id"`
+	Name     string  `json:"name"`
 }
 
 // GetTypename returns AccessProviderWhoListItemItemDataSource.Typename, and is useful for accessing the field via an interface.
 func (v *AccessProviderWhoListItemItemDataSource) GetTypename() *string { return v.Typename }
 
+// GetId returns AccessProviderWhoListItemItemDataSource.Id, and is useful for accessing the field via an interface.
+func (v *AccessProviderWhoListItemItemDataSource) GetId() string { return v.Id }
+
+// GetName returns AccessProviderWhoListItemItemDataSource.Name, and is useful for accessing the field via an interface.
+func (v *AccessProviderWhoListItemItemDataSource) GetName() string { return v.Name }
+
 // AccessProviderWhoListItemItemGroup includes the requested fields of the GraphQL type Group.
 type AccessProviderWhoListItemItemGroup struct {
 	Typename      *string                                         `json:"__typename"`
@@ -13330,6 +13338,41 @@ func (v *CreateGrantCategoryCreateGrantCategory) GetAllowedWhatItems() GrantCate
 	return v.GrantCategoryDetails.AllowedWhatItems
 }
 
+// GetCanRequestAccess returns CreateGrantCategoryCreateGrantCategory.CanRequestAccess, and is useful for accessing the field via an interface.
+func (v *CreateGrantCategoryCreateGrantCategory) GetCanRequestAccess() bool {
+	return v.GrantCategoryDetails.CanRequestAccess
+}
+
+// GetDescriptionMandatory returns CreateGrantCategoryCreateGrantCategory.DescriptionMandatory, and is useful for accessing the field via an interface.
+func (v *CreateGrantCategoryCreateGrantCategory) GetDescriptionMandatory() bool {
+	return v.GrantCategoryDetails.DescriptionMandatory
+}
+
+// GetNameRegEx returns CreateGrantCategoryCreateGrantCategory.NameRegEx, and is useful for accessing the field via an interface.
+func (v *CreateGrantCategoryCreateGrantCategory) GetNameRegEx() *string {
+	return v.GrantCategoryDetails.NameRegEx
+}
+
+// GetNameRegExMsg returns CreateGrantCategoryCreateGrantCategory.NameRegExMsg, and is useful for accessing the field via an interface.
+func (v *CreateGrantCategoryCreateGrantCategory) GetNameRegExMsg() *string {
+	return v.GrantCategoryDetails.NameRegExMsg
+}
+
+// GetNamingHintRegEx returns CreateGrantCategoryCreateGrantCategory.NamingHintRegEx, and is useful for accessing the field via an interface.
+func (v *CreateGrantCategoryCreateGrantCategory) GetNamingHintRegEx() *string {
+	return v.GrantCategoryDetails.NamingHintRegEx
+}
+
+// GetNamingHintRegExMsg returns CreateGrantCategoryCreateGrantCategory.NamingHintRegExMsg, and is useful for accessing the field via an interface.
+func (v *CreateGrantCategoryCreateGrantCategory) GetNamingHintRegExMsg() *string {
+	return v.GrantCategoryDetails.NamingHintRegExMsg
+}
+
+// GetLocksOnCreate returns CreateGrantCategoryCreateGrantCategory.LocksOnCreate, and is useful for accessing the field via an interface.
+func (v *CreateGrantCategoryCreateGrantCategory) GetLocksOnCreate() []AccessProviderLock {
+	return v.GrantCategoryDetails.LocksOnCreate
+}
+
 func (v *CreateGrantCategoryCreateGrantCategory) UnmarshalJSON(b []byte) error {
 
 	if string(b) == "null" {
@@ -13385,6 +13428,20 @@ type __premarshalCreateGrantCategoryCreateGrantCategory struct {
 	AllowedWhoItems GrantCategoryDetailsAllowedWhoItemsGrantCategoryAllowedWhoItems `json:"allowedWhoItems"`
 
 	AllowedWhatItems GrantCategoryDetailsAllowedWhatItemsGrantCategoryAllowedWhatItems `json:"allowedWhatItems"`
+
+	CanRequestAccess bool `json:"canRequestAccess"`
+
+	DescriptionMandatory bool `json:"descriptionMandatory"`
+
+	NameRegEx *string `json:"nameRegEx"`
+
+	NameRegExMsg *string `json:"nameRegExMsg"`
+
+	NamingHintRegEx *string `json:"namingHintRegEx"`
+
+	NamingHintRegExMsg *string `json:"namingHintRegExMsg"`
+
+	LocksOnCreate []AccessProviderLock `json:"locksOnCreate"`
 }
 
 func (v *CreateGrantCategoryCreateGrantCategory) MarshalJSON() ([]byte, error) {
@@ -13413,6 +13470,13 @@ func (v *CreateGrantCategoryCreateGrantCategory) __premarshalJSON() (*__premarsh
 	retval.DefaultTypePerDataSource = v.GrantCategoryDetails.DefaultTypePerDataSource
 	retval.AllowedWhoItems = v.GrantCategoryDetails.AllowedWhoItems
 	retval.AllowedWhatItems = v.GrantCategoryDetails.AllowedWhatItems
+	retval.CanRequestAccess = v.GrantCategoryDetails.CanRequestAccess
+	retval.DescriptionMandatory = v.GrantCategoryDetails.DescriptionMandatory
+	retval.NameRegEx = v.GrantCategoryDetails.NameRegEx
+	retval.NameRegExMsg = v.GrantCategoryDetails.NameRegExMsg
+	retval.NamingHintRegEx = v.GrantCategoryDetails.NamingHintRegEx
+	retval.NamingHintRegExMsg = v.GrantCategoryDetails.NamingHintRegExMsg
+	retval.LocksOnCreate = v.GrantCategoryDetails.LocksOnCreate
 	return &retval, nil
 }
 
@@ -23383,6 +23447,398 @@ func (v *GetAccessProviderResponse) __premarshalJSON() (*__premarshalGetAccessPr
 	return &retval, nil
 }
 
+// GetAccessProviderTagsAccessProvider includes the requested fields of the GraphQL type AccessProvider.
+type GetAccessProviderTagsAccessProvider struct {
+	Typename *string                                      `json:"__typename"`
+	Tags     []GetAccessProviderTagsAccessProviderTagsTag `json:"tags"`
+}
+
+// GetTypename returns GetAccessProviderTagsAccessProvider.Typename, and is useful for accessing the field via an interface.
+func (v *GetAccessProviderTagsAccessProvider) GetTypename() *string { return v.Typename }
+
+// GetTags returns GetAccessProviderTagsAccessProvider.Tags, and is useful for accessing the field via an interface.
+func (v *GetAccessProviderTagsAccessProvider) GetTags() []GetAccessProviderTagsAccessProviderTagsTag {
+	return v.Tags
+}
+
+// GetAccessProviderTagsAccessProviderAccessProviderResult includes the requested fields of the GraphQL interface AccessProviderResult.
+//
+// GetAccessProviderTagsAccessProviderAccessProviderResult is implemented by the following types:
+// GetAccessProviderTagsAccessProvider
+// GetAccessProviderTagsAccessProviderInvalidInputError
+// GetAccessProviderTagsAccessProviderNotFoundError
+// GetAccessProviderTagsAccessProviderPermissionDeniedError
+type GetAccessProviderTagsAccessProviderAccessProviderResult interface {
+	implementsGraphQLInterfaceGetAccessProviderTagsAccessProviderAccessProviderResult()
+	// GetTypename returns the receiver's concrete GraphQL type-name (see interface doc for possible values).
+	GetTypename() *string
+}
+
+func (v *GetAccessProviderTagsAccessProvider) implementsGraphQLInterfaceGetAccessProviderTagsAccessProviderAccessProviderResult() {
+}
+func (v *GetAccessProviderTagsAccessProviderInvalidInputError) implementsGraphQLInterfaceGetAccessProviderTagsAccessProviderAccessProviderResult() {
+}
+func (v *GetAccessProviderTagsAccessProviderNotFoundError) implementsGraphQLInterfaceGetAccessProviderTagsAccessProviderAccessProviderResult() {
+}
+func (v *GetAccessProviderTagsAccessProviderPermissionDeniedError) implementsGraphQLInterfaceGetAccessProviderTagsAccessProviderAccessProviderResult() {
+}
+
+func __unmarshalGetAccessProviderTagsAccessProviderAccessProviderResult(b []byte, v *GetAccessProviderTagsAccessProviderAccessProviderResult) error {
+	if string(b) == "null" {
+		return nil
+	}
+
+	var tn struct {
+		TypeName string `json:"__typename"`
+	}
+	err := json.Unmarshal(b, &tn)
+	if err != nil {
+		return err
+	}
+
+	switch tn.TypeName {
+	case "AccessProvider":
+		*v = new(GetAccessProviderTagsAccessProvider)
+		return json.Unmarshal(b, *v)
+	case "InvalidInputError":
+		*v = new(GetAccessProviderTagsAccessProviderInvalidInputError)
+		return json.Unmarshal(b, *v)
+	case "NotFoundError":
+		*v = new(GetAccessProviderTagsAccessProviderNotFoundError)
+		return json.Unmarshal(b, *v)
+	case "PermissionDeniedError":
+		*v = new(GetAccessProviderTagsAccessProviderPermissionDeniedError)
+		return json.Unmarshal(b, *v)
+	case "":
+		return fmt.Errorf(
+			"response was missing AccessProviderResult.__typename")
+	default:
+		return fmt.Errorf(
+			`unexpected concrete type for GetAccessProviderTagsAccessProviderAccessProviderResult: "%v"`, tn.TypeName)
+	}
+}
+
+func __marshalGetAccessProviderTagsAccessProviderAccessProviderResult(v *GetAccessProviderTagsAccessProviderAccessProviderResult) ([]byte, error) {
+
+	var typename string
+	switch v := (*v).(type) {
+	case *GetAccessProviderTagsAccessProvider:
+		typename = "AccessProvider"
+
+		result := struct {
+			TypeName string `json:"__typename"`
+			*GetAccessProviderTagsAccessProvider
+		}{typename, v}
+		return json.Marshal(result)
+	case *GetAccessProviderTagsAccessProviderInvalidInputError:
+		typename = "InvalidInputError"
+
+		result := struct {
+			TypeName string `json:"__typename"`
+			*GetAccessProviderTagsAccessProviderInvalidInputError
+		}{typename, v}
+		return json.Marshal(result)
+	case *GetAccessProviderTagsAccessProviderNotFoundError:
+		typename = "NotFoundError"
+
+		premarshaled, err := v.__premarshalJSON()
+		if err != nil {
+			return nil, err
+		}
+		result := struct {
+			TypeName string `json:"__typename"`
+			*__premarshalGetAccessProviderTagsAccessProviderNotFoundError
+		}{typename, premarshaled}
+		return json.Marshal(result)
+	case *GetAccessProviderTagsAccessProviderPermissionDeniedError:
+		typename = "PermissionDeniedError"
+
+		premarshaled, err := v.__premarshalJSON()
+		if err != nil {
+			return nil, err
+		}
+		result := struct {
+			TypeName string `json:"__typename"`
+			*__premarshalGetAccessProviderTagsAccessProviderPermissionDeniedError
+		}{typename, premarshaled}
+		return json.Marshal(result)
+	case nil:
+		return []byte("null"), nil
+	default:
+		return nil, fmt.Errorf(
+			`unexpected concrete type for GetAccessProviderTagsAccessProviderAccessProviderResult: "%T"`, v)
+	}
+}
+
+// GetAccessProviderTagsAccessProviderInvalidInputError includes the requested fields of the GraphQL type InvalidInputError.
+type GetAccessProviderTagsAccessProviderInvalidInputError struct {
+	Typename *string `json:"__typename"`
+}
+
+// GetTypename returns GetAccessProviderTagsAccessProviderInvalidInputError.Typename, and is useful for accessing the field via an interface.
+func (v *GetAccessProviderTagsAccessProviderInvalidInputError) GetTypename() *string {
+	return v.Typename
+}
+
+// GetAccessProviderTagsAccessProviderNotFoundError includes the requested fields of the GraphQL type NotFoundError.
+type GetAccessProviderTagsAccessProviderNotFoundError struct {
+	Typename      *string `json:"__typename"`
+	NotFoundError `json:"-"`
+}
+
+// GetTypename returns GetAccessProviderTagsAccessProviderNotFoundError.Typename, and is useful for accessing the field via an interface.
+func (v *GetAccessProviderTagsAccessProviderNotFoundError) GetTypename() *string {
+	return v.Typename
+}
+
+// GetMessage returns GetAccessProviderTagsAccessProviderNotFoundError.Message, and is useful for accessing the field via an interface.
+func (v *GetAccessProviderTagsAccessProviderNotFoundError) GetMessage() string {
+	return v.NotFoundError.Message
+}
+
+func (v *GetAccessProviderTagsAccessProviderNotFoundError) UnmarshalJSON(b []byte) error {
+
+	if string(b) == "null" {
+		return nil
+	}
+
+	var firstPass struct {
+		*GetAccessProviderTagsAccessProviderNotFoundError
+		graphql.NoUnmarshalJSON
+	}
+	firstPass.GetAccessProviderTagsAccessProviderNotFoundError = v
+
+	err := json.Unmarshal(b, &firstPass)
+	if err != nil {
+		return err
+	}
+
+	err = json.Unmarshal(
+		b, &v.NotFoundError)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+type __premarshalGetAccessProviderTagsAccessProviderNotFoundError struct {
+	Typename *string `json:"__typename"`
+
+	Message string `json:"message"`
+}
+
+func (v *GetAccessProviderTagsAccessProviderNotFoundError) MarshalJSON() ([]byte, error) {
+	premarshaled, err := v.__premarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(premarshaled)
+}
+
+func (v *GetAccessProviderTagsAccessProviderNotFoundError) __premarshalJSON() (*__premarshalGetAccessProviderTagsAccessProviderNotFoundError, error) {
+	var retval __premarshalGetAccessProviderTagsAccessProviderNotFoundError
+
+	retval.Typename = v.Typename
+	retval.Message = v.NotFoundError.Message
+	return &retval, nil
+}
+
+// GetAccessProviderTagsAccessProviderPermissionDeniedError includes the requested fields of the GraphQL type PermissionDeniedError.
+type GetAccessProviderTagsAccessProviderPermissionDeniedError struct {
+	Typename              *string `json:"__typename"`
+	PermissionDeniedError `json:"-"`
+}
+
+// GetTypename returns GetAccessProviderTagsAccessProviderPermissionDeniedError.Typename, and is useful for accessing the field via an interface.
+func (v *GetAccessProviderTagsAccessProviderPermissionDeniedError) GetTypename() *string {
+	return v.Typename
+}
+
+// GetMessage returns GetAccessProviderTagsAccessProviderPermissionDeniedError.Message, and is useful for accessing the field via an interface.
+func (v *GetAccessProviderTagsAccessProviderPermissionDeniedError) GetMessage() string {
+	return v.PermissionDeniedError.Message
+}
+
+func (v *GetAccessProviderTagsAccessProviderPermissionDeniedError) UnmarshalJSON(b []byte) error {
+
+	if string(b) == "null" {
+		return nil
+	}
+
+	var firstPass struct {
+		*GetAccessProviderTagsAccessProviderPermissionDeniedError
+		graphql.NoUnmarshalJSON
+	}
+	firstPass.GetAccessProviderTagsAccessProviderPermissionDeniedError = v
+
+	err := json.Unmarshal(b, &firstPass)
+	if err != nil {
+		return err
+	}
+
+	err = json.Unmarshal(
+		b, &v.PermissionDeniedError)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+type __premarshalGetAccessProviderTagsAccessProviderPermissionDeniedError struct {
+	Typename *string `json:"__typename"`
+
+	Message string `json:"message"`
+}
+
+func (v *GetAccessProviderTagsAccessProviderPermissionDeniedError) MarshalJSON() ([]byte, error) {
+	premarshaled, err := v.__premarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(premarshaled)
+}
+
+func (v *GetAccessProviderTagsAccessProviderPermissionDeniedError) __premarshalJSON() (*__premarshalGetAccessProviderTagsAccessProviderPermissionDeniedError, error) {
+	var retval __premarshalGetAccessProviderTagsAccessProviderPermissionDeniedError
+
+	retval.Typename = v.Typename
+	retval.Message = v.PermissionDeniedError.Message
+	return &retval, nil
+}
+
+// GetAccessProviderTagsAccessProviderTagsTag includes the requested fields of the GraphQL type Tag.
+type GetAccessProviderTagsAccessProviderTagsTag struct {
+	Tag `json:"-"`
+}
+
+// GetKey returns GetAccessProviderTagsAccessProviderTagsTag.Key, and is useful for accessing the field via an interface.
+func (v *GetAccessProviderTagsAccessProviderTagsTag) GetKey() string { return v.Tag.Key }
+
+// GetStringValue returns GetAccessProviderTagsAccessProviderTagsTag.StringValue, and is useful for accessing the field via an interface.
+func (v *GetAccessProviderTagsAccessProviderTagsTag) GetStringValue() string {
+	return v.Tag.StringValue
+}
+
+func (v *GetAccessProviderTagsAccessProviderTagsTag) UnmarshalJSON(b []byte) error {
+
+	if string(b) == "null" {
+		return nil
+	}
+
+	var firstPass struct {
+		*GetAccessProviderTagsAccessProviderTagsTag
+		graphql.NoUnmarshalJSON
+	}
+	firstPass.GetAccessProviderTagsAccessProviderTagsTag = v
+
+	err := json.Unmarshal(b, &firstPass)
+	if err != nil {
+		return err
+	}
+
+	err = json.Unmarshal(
+		b, &v.Tag)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+type __premarshalGetAccessProviderTagsAccessProviderTagsTag struct {
+	Key string `json:"key"`
+
+	StringValue string `json:"stringValue"`
+}
+
+func (v *GetAccessProviderTagsAccessProviderTagsTag) MarshalJSON() ([]byte, error) {
+	premarshaled, err := v.__premarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(premarshaled)
+}
+
+func (v *GetAccessProviderTagsAccessProviderTagsTag) __premarshalJSON() (*__premarshalGetAccessProviderTagsAccessProviderTagsTag, error) {
+	var retval __premarshalGetAccessProviderTagsAccessProviderTagsTag
+
+	retval.Key = v.Tag.Key
+	retval.StringValue = v.Tag.StringValue
+	return &retval, nil
+}
+
+// GetAccessProviderTagsResponse is returned by GetAccessProviderTags on success.
+type GetAccessProviderTagsResponse struct {
+	AccessProvider GetAccessProviderTagsAccessProviderAccessProviderResult `json:"-"`
+}
+
+// GetAccessProvider returns GetAccessProviderTagsResponse.AccessProvider, and is useful for accessing the field via an interface.
+func (v *GetAccessProviderTagsResponse) GetAccessProvider() GetAccessProviderTagsAccessProviderAccessProviderResult {
+	return v.AccessProvider
+}
+
+func (v *GetAccessProviderTagsResponse) UnmarshalJSON(b []byte) error {
+
+	if string(b) == "null" {
+		return nil
+	}
+
+	var firstPass struct {
+		*GetAccessProviderTagsResponse
+		AccessProvider json.RawMessage `json:"accessProvider"`
+		graphql.NoUnmarshalJSON
+	}
+	firstPass.GetAccessProviderTagsResponse = v
+
+	err := json.Unmarshal(b, &firstPass)
+	if err != nil {
+		return err
+	}
+
+	{
+		dst := &v.AccessProvider
+		src := firstPass.AccessProvider
+		if len(src) != 0 && string(src) != "null" {
+			err = __unmarshalGetAccessProviderTagsAccessProviderAccessProviderResult(
+				src, dst)
+			if err != nil {
+				return fmt.Errorf(
+					"unable to unmarshal GetAccessProviderTagsResponse.AccessProvider: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+type __premarshalGetAccessProviderTagsResponse struct {
+	AccessProvider json.RawMessage `json:"accessProvider"`
+}
+
+func (v *GetAccessProviderTagsResponse) MarshalJSON() ([]byte, error) {
+	premarshaled, err := v.__premarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(premarshaled)
+}
+
+func (v *GetAccessProviderTagsResponse) __premarshalJSON() (*__premarshalGetAccessProviderTagsResponse, error) {
+	var retval __premarshalGetAccessProviderTagsResponse
+
+	{
+
+		dst := &retval.AccessProvider
+		src := v.AccessProvider
+		var err error
+		*dst, err = __marshalGetAccessProviderTagsAccessProviderAccessProviderResult(
+			&src)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"unable to marshal GetAccessProviderTagsResponse.AccessProvider: %w", err)
+		}
+	}
+	return &retval, nil
+}
+
 // GetAccessProviderWhatAccessProvidersAccessProvider includes the requested fields of the GraphQL type AccessProvider.
 type GetAccessProviderWhatAccessProvidersAccessProvider struct {
 	Typename            *string                                                                           `json:"__typename"`
@@ -25482,6 +25938,612 @@ type GetDataObjectResponse struct {
 // GetDataObject returns GetDataObjectResponse.DataObject, and is useful for accessing the field via an interface.
 func (v *GetDataObjectResponse) GetDataObject() GetDataObjectDataObject { return v.DataObject }
 
+// GetDataObjectTagsDataObject includes the requested fields of the GraphQL type DataObject.
+type GetDataObjectTagsDataObject struct {
+	Tags []GetDataObjectTagsDataObjectTagsTag `json:"tags"`
+}
+
+// GetTags returns GetDataObjectTagsDataObject.Tags, and is useful for accessing the field via an interface.
+func (v *GetDataObjectTagsDataObject) GetTags() []GetDataObjectTagsDataObjectTagsTag {
+	return v.Tags
+}
+
+// GetDataObjectTagsDataObjectTagsTag includes the requested fields of the GraphQL type Tag.
+type GetDataObjectTagsDataObjectTagsTag struct {
+	Tag `json:"-"`
+}
+
+// GetKey returns GetDataObjectTagsDataObjectTagsTag.Key, and is useful for accessing the field via an interface.
+func (v *GetDataObjectTagsDataObjectTagsTag) GetKey() string { return v.Tag.Key }
+
+// GetStringValue returns GetDataObjectTagsDataObjectTagsTag.StringValue, and is useful for accessing the field via an interface.
+func (v *GetDataObjectTagsDataObjectTagsTag) GetStringValue() string { return v.Tag.StringValue }
+
+func (v *GetDataObjectTagsDataObjectTagsTag) UnmarshalJSON(b []byte) error {
+
+	if string(b) == "null" {
+		return nil
+	}
+
+	var firstPass struct {
+		*GetDataObjectTagsDataObjectTagsTag
+		graphql.NoUnmarshalJSON
+	}
+	firstPass.GetDataObjectTagsDataObjectTagsTag = v
+
+	err := json.Unmarshal(b, &firstPass)
+	if err != nil {
+		return err
+	}
+
+	err = json.Unmarshal(
+		b, &v.Tag)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+type __premarshalGetDataObjectTagsDataObjectTagsTag struct {
+	Key string `json:"key"`
+
+	StringValue string `json:"stringValue"`
+}
+
+func (v *GetDataObjectTagsDataObjectTagsTag) MarshalJSON() ([]byte, error) {
+	premarshaled, err := v.__premarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(premarshaled)
+}
+
+func (v *GetDataObjectTagsDataObjectTagsTag) __premarshalJSON() (*__premarshalGetDataObjectTagsDataObjectTagsTag, error) {
+	var retval __premarshalGetDataObjectTagsDataObjectTagsTag
+
+	retval.Key = v.Tag.Key
+	retval.StringValue = v.Tag.StringValue
+	return &retval, nil
+}
+
+// GetDataObjectTagsResponse is returned by GetDataObjectTags on success.
+type GetDataObjectTagsResponse struct {
+	DataObject GetDataObjectTagsDataObject `json:"dataObject"`
+}
+
+// GetDataObject returns GetDataObjectTagsResponse.DataObject, and is useful for accessing the field via an interface.
+func (v *GetDataObjectTagsResponse) GetDataObject() GetDataObjectTagsDataObject {
+	return v.DataObject
+}
+
+// GetDataSourceCapabilitiesDataSource includes the requested fields of the GraphQL type DataSource.
+type GetDataSourceCapabilitiesDataSource struct {
+	Typename            *string `json:"__typename"`
+	DataSource          `json:"-"`
+	SupportedFeatures   []DataSourceFeatures                                                       `json:"supportedFeatures"`
+	AccessProviderTypes []GetDataSourceCapabilitiesDataSourceAccessProviderTypesAccessProviderType `json:"accessProviderTypes"`
+	MaskingMetadata     *GetDataSourceCapabilitiesDataSourceMaskingMetadata                        `json:"maskingMetadata"`
+}
+
+// GetTypename returns GetDataSourceCapabilitiesDataSource.Typename, and is useful for accessing the field via an interface.
+func (v *GetDataSourceCapabilitiesDataSource) GetTypename() *string { return v.Typename }
+
+// GetId returns GetDataSourceCapabilitiesDataSource.Id, and is useful for accessing the field via an interface.
+func (v *GetDataSourceCapabilitiesDataSource) GetId() string { return v.DataSource.Id }
+
+// GetName returns GetDataSourceCapabilitiesDataSource.Name, and is useful for accessing the field via an interface.
+func (v *GetDataSourceCapabilitiesDataSource) GetName() string { return v.DataSource.Name }
+
+// GetType returns GetDataSourceCapabilitiesDataSource.Type, and is useful for accessing the field via an interface.
+func (v *GetDataSourceCapabilitiesDataSource) GetType() string { return v.DataSource.Type }
+
+// GetDescription returns GetDataSourceCapabilitiesDataSource.Description, and is useful for accessing the field via an interface.
+func (v *GetDataSourceCapabilitiesDataSource) GetDescription() string {
+	return v.DataSource.Description
+}
+
+// GetCreatedAt returns GetDataSourceCapabilitiesDataSource.CreatedAt, and is useful for accessing the field via an interface.
+func (v *GetDataSourceCapabilitiesDataSource) GetCreatedAt() time.Time { return v.DataSource.CreatedAt }
+
+// GetModifiedAt returns GetDataSourceCapabilitiesDataSource.ModifiedAt, and is useful for accessing the field via an interface.
+func (v *GetDataSourceCapabilitiesDataSource) GetModifiedAt() time.Time {
+	return v.DataSource.ModifiedAt
+}
+
+// GetSyncMethod returns GetDataSourceCapabilitiesDataSource.SyncMethod, and is useful for accessing the field via an interface.
+func (v *GetDataSourceCapabilitiesDataSource) GetSyncMethod() DataSourceSyncMethod {
+	return v.DataSource.SyncMethod
+}
+
+// GetParent returns GetDataSourceCapabilitiesDataSource.Parent, and is useful for accessing the field via an interface.
+func (v *GetDataSourceCapabilitiesDataSource) GetParent() *DataSourceParentDataSource {
+	return v.DataSource.Parent
+}
+
+// GetSupportedFeatures returns GetDataSourceCapabilitiesDataSource.SupportedFeatures, and is useful for accessing the field via an interface.
+func (v *GetDataSourceCapabilitiesDataSource) GetSupportedFeatures() []DataSourceFeatures {
+	return v.SupportedFeatures
+}
+
+// GetAccessProviderTypes returns GetDataSourceCapabilitiesDataSource.AccessProviderTypes, and is useful for accessing the field via an interface.
+func (v *GetDataSourceCapabilitiesDataSource) GetAccessProviderTypes() []GetDataSourceCapabilitiesDataSourceAccessProviderTypesAccessProviderType {
+	return v.AccessProviderTypes
+}
+
+// GetMaskingMetadata returns GetDataSourceCapabilitiesDataSource.MaskingMetadata, and is useful for accessing the field via an interface.
+func (v *GetDataSourceCapabilitiesDataSource) GetMaskingMetadata() *GetDataSourceCapabilitiesDataSourceMaskingMetadata {
+	return v.MaskingMetadata
+}
+
+func (v *GetDataSourceCapabilitiesDataSource) UnmarshalJSON(b []byte) error {
+
+	if string(b) == "null" {
+		return nil
+	}
+
+	var firstPass struct {
+		*GetDataSourceCapabilitiesDataSource
+		graphql.NoUnmarshalJSON
+	}
+	firstPass.GetDataSourceCapabilitiesDataSource = v
+
+	err := json.Unmarshal(b, &firstPass)
+	if err != nil {
+		return err
+	}
+
+	err = json.Unmarshal(
+		b, &v.DataSource)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+type __premarshalGetDataSourceCapabilitiesDataSource struct {
+	Typename *string `json:"__typename"`
+
+	Id string `json:"id"`
+
+	Name string `json:"name"`
+
+	Type string `json:"type"`
+
+	Description string `json:"description"`
+
+	CreatedAt time.Time `json:"createdAt"`
+
+	ModifiedAt time.Time `json:"modifiedAt"`
+
+	SyncMethod DataSourceSyncMethod `json:"syncMethod"`
+
+	Parent *DataSourceParentDataSource `json:"parent"`
+
+	SupportedFeatures []DataSourceFeatures `json:"supportedFeatures"`
+
+	AccessProviderTypes []GetDataSourceCapabilitiesDataSourceAccessProviderTypesAccessProviderType `json:"accessProviderTypes"`
+
+	MaskingMetadata *GetDataSourceCapabilitiesDataSourceMaskingMetadata `json:"maskingMetadata"`
+}
+
+func (v *GetDataSourceCapabilitiesDataSource) MarshalJSON() ([]byte, error) {
+	premarshaled, err := v.__premarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(premarshaled)
+}
+
+func (v *GetDataSourceCapabilitiesDataSource) __premarshalJSON() (*__premarshalGetDataSourceCapabilitiesDataSource, error) {
+	var retval __premarshalGetDataSourceCapabilitiesDataSource
+
+	retval.Typename = v.Typename
+	retval.Id = v.DataSource.Id
+	retval.Name = v.DataSource.Name
+	retval.Type = v.DataSource.Type
+	retval.Description = v.DataSource.Description
+	retval.CreatedAt = v.DataSource.CreatedAt
+	retval.ModifiedAt = v.DataSource.ModifiedAt
+	retval.SyncMethod = v.DataSource.SyncMethod
+	retval.Parent = v.DataSource.Parent
+	retval.SupportedFeatures = v.SupportedFeatures
+	retval.AccessProviderTypes = v.AccessProviderTypes
+	retval.MaskingMetadata = v.MaskingMetadata
+	return &retval, nil
+}
+
+// GetDataSourceCapabilitiesDataSourceAccessProviderTypesAccessProviderType includes the requested fields of the GraphQL type AccessProviderType.
+type GetDataSourceCapabilitiesDataSourceAccessProviderTypesAccessProviderType struct {
+	Type *string `json:"type"`
+}
+
+// GetType returns GetDataSourceCapabilitiesDataSourceAccessProviderTypesAccessProviderType.Type, and is useful for accessing the field via an interface.
+func (v *GetDataSourceCapabilitiesDataSourceAccessProviderTypesAccessProviderType) GetType() *string {
+	return v.Type
+}
+
+// GetDataSourceCapabilitiesDataSourceDataSourceResult includes the requested fields of the GraphQL interface DataSourceResult.
+//
+// GetDataSourceCapabilitiesDataSourceDataSourceResult is implemented by the following types:
+// GetDataSourceCapabilitiesDataSource
+// GetDataSourceCapabilitiesDataSourceInvalidInputError
+// GetDataSourceCapabilitiesDataSourceNotFoundError
+// GetDataSourceCapabilitiesDataSourcePermissionDeniedError
+type GetDataSourceCapabilitiesDataSourceDataSourceResult interface {
+	implementsGraphQLInterfaceGetDataSourceCapabilitiesDataSourceDataSourceResult()
+	// GetTypename returns the receiver's concrete GraphQL type-name (see interface doc for possible values).
+	GetTypename() *string
+}
+
+func (v *GetDataSourceCapabilitiesDataSource) implementsGraphQLInterfaceGetDataSourceCapabilitiesDataSourceDataSourceResult() {
+}
+func (v *GetDataSourceCapabilitiesDataSourceInvalidInputError) implementsGraphQLInterfaceGetDataSourceCapabilitiesDataSourceDataSourceResult() {
+}
+func (v *GetDataSourceCapabilitiesDataSourceNotFoundError) implementsGraphQLInterfaceGetDataSourceCapabilitiesDataSourceDataSourceResult() {
+}
+func (v *GetDataSourceCapabilitiesDataSourcePermissionDeniedError) implementsGraphQLInterfaceGetDataSourceCapabilitiesDataSourceDataSourceResult() {
+}
+
+func __unmarshalGetDataSourceCapabilitiesDataSourceDataSourceResult(b []byte, v *GetDataSourceCapabilitiesDataSourceDataSourceResult) error {
+	if string(b) == "null" {
+		return nil
+	}
+
+	var tn struct {
+		TypeName string `json:"__typename"`
+	}
+	err := json.Unmarshal(b, &tn)
+	if err != nil {
+		return err
+	}
+
+	switch tn.TypeName {
+	case "DataSource":
+		*v = new(GetDataSourceCapabilitiesDataSource)
+		return json.Unmarshal(b, *v)
+	case "InvalidInputError":
+		*v = new(GetDataSourceCapabilitiesDataSourceInvalidInputError)
+		return json.Unmarshal(b, *v)
+	case "NotFoundError":
+		*v = new(GetDataSourceCapabilitiesDataSourceNotFoundError)
+		return json.Unmarshal(b, *v)
+	case "PermissionDeniedError":
+		*v = new(GetDataSourceCapabilitiesDataSourcePermissionDeniedError)
+		return json.Unmarshal(b, *v)
+	case "":
+		return fmt.Errorf(
+			"response was missing DataSourceResult.__typename")
+	default:
+		return fmt.Errorf(
+			`unexpected concrete type for GetDataSourceCapabilitiesDataSourceDataSourceResult: "%v"`, tn.TypeName)
+	}
+}
+
+func __marshalGetDataSourceCapabilitiesDataSourceDataSourceResult(v *GetDataSourceCapabilitiesDataSourceDataSourceResult) ([]byte, error) {
+
+	var typename string
+	switch v := (*v).(type) {
+	case *GetDataSourceCapabilitiesDataSource:
+		typename = "DataSource"
+
+		premarshaled, err := v.__premarshalJSON()
+		if err != nil {
+			return nil, err
+		}
+		result := struct {
+			TypeName string `json:"__typename"`
+			*__premarshalGetDataSourceCapabilitiesDataSource
+		}{typename, premarshaled}
+		return json.Marshal(result)
+	case *GetDataSourceCapabilitiesDataSourceInvalidInputError:
+		typename = "InvalidInputError"
+
+		result := struct {
+			TypeName string `json:"__typename"`
+			*GetDataSourceCapabilitiesDataSourceInvalidInputError
+		}{typename, v}
+		return json.Marshal(result)
+	case *GetDataSourceCapabilitiesDataSourceNotFoundError:
+		typename = "NotFoundError"
+
+		premarshaled, err := v.__premarshalJSON()
+		if err != nil {
+			return nil, err
+		}
+		result := struct {
+			TypeName string `json:"__typename"`
+			*__premarshalGetDataSourceCapabilitiesDataSourceNotFoundError
+		}{typename, premarshaled}
+		return json.Marshal(result)
+	case *GetDataSourceCapabilitiesDataSourcePermissionDeniedError:
+		typename = "PermissionDeniedError"
+
+		premarshaled, err := v.__premarshalJSON()
+		if err != nil {
+			return nil, err
+		}
+		result := struct {
+			TypeName string `json:"__typename"`
+			*__premarshalGetDataSourceCapabilitiesDataSourcePermissionDeniedError
+		}{typename, premarshaled}
+		return json.Marshal(result)
+	case nil:
+		return []byte("null"), nil
+	default:
+		return nil, fmt.Errorf(
+			`unexpected concrete type for GetDataSourceCapabilitiesDataSourceDataSourceResult: "%T"`, v)
+	}
+}
+
+// GetDataSourceCapabilitiesDataSourceInvalidInputError includes the requested fields of the GraphQL type InvalidInputError.
+type GetDataSourceCapabilitiesDataSourceInvalidInputError struct {
+	Typename *string `json:"__typename"`
+}
+
+// GetTypename returns GetDataSourceCapabilitiesDataSourceInvalidInputError.Typename, and is useful for accessing the field via an interface.
+func (v *GetDataSourceCapabilitiesDataSourceInvalidInputError) GetTypename() *string {
+	return v.Typename
+}
+
+// GetDataSourceCapabilitiesDataSourceMaskingMetadata includes the requested fields of the GraphQL type MaskingMetadata.
+type GetDataSourceCapabilitiesDataSourceMaskingMetadata struct {
+	MaskingMetadata `json:"-"`
+}
+
+// GetDefaultMaskExternalName returns GetDataSourceCapabilitiesDataSourceMaskingMetadata.DefaultMaskExternalName, and is useful for accessing the field via an interface.
+func (v *GetDataSourceCapabilitiesDataSourceMaskingMetadata) GetDefaultMaskExternalName() *string {
+	return v.MaskingMetadata.DefaultMaskExternalName
+}
+
+// GetMaskTypes returns GetDataSourceCapabilitiesDataSourceMaskingMetadata.MaskTypes, and is useful for accessing the field via an interface.
+func (v *GetDataSourceCapabilitiesDataSourceMaskingMetadata) GetMaskTypes() []MaskingMetadataMaskTypesMaskType {
+	return v.MaskingMetadata.MaskTypes
+}
+
+func (v *GetDataSourceCapabilitiesDataSourceMaskingMetadata) UnmarshalJSON(b []byte) error {
+
+	if string(b) == "null" {
+		return nil
+	}
+
+	var firstPass struct {
+		*GetDataSourceCapabilitiesDataSourceMaskingMetadata
+		graphql.NoUnmarshalJSON
+	}
+	firstPass.GetDataSourceCapabilitiesDataSourceMaskingMetadata = v
+
+	err := json.Unmarshal(b, &firstPass)
+	if err != nil {
+		return err
+	}
+
+	err = json.Unmarshal(
+		b, &v.MaskingMetadata)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+type __premarshalGetDataSourceCapabilitiesDataSourceMaskingMetadata struct {
+	DefaultMaskExternalName *string `json:"defaultMaskExternalName"`
+
+	MaskTypes []MaskingMetadataMaskTypesMaskType `json:"maskTypes"`
+}
+
+func (v *GetDataSourceCapabilitiesDataSourceMaskingMetadata) MarshalJSON() ([]byte, error) {
+	premarshaled, err := v.__premarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(premarshaled)
+}
+
+func (v *GetDataSourceCapabilitiesDataSourceMaskingMetadata) __premarshalJSON() (*__premarshalGetDataSourceCapabilitiesDataSourceMaskingMetadata, error) {
+	var retval __premarshalGetDataSourceCapabilitiesDataSourceMaskingMetadata
+
+	retval.DefaultMaskExternalName = v.MaskingMetadata.DefaultMaskExternalName
+	retval.MaskTypes = v.MaskingMetadata.MaskTypes
+	return &retval, nil
+}
+
+// GetDataSourceCapabilitiesDataSourceNotFoundError includes the requested fields of the GraphQL type NotFoundError.
+type GetDataSourceCapabilitiesDataSourceNotFoundError struct {
+	Typename      *string `json:"__typename"`
+	NotFoundError `json:"-"`
+}
+
+// GetTypename returns GetDataSourceCapabilitiesDataSourceNotFoundError.Typename, and is useful for accessing the field via an interface.
+func (v *GetDataSourceCapabilitiesDataSourceNotFoundError) GetTypename() *string { return v.Typename }
+
+// GetMessage returns GetDataSourceCapabilitiesDataSourceNotFoundError.Message, and is useful for accessing the field via an interface.
+func (v *GetDataSourceCapabilitiesDataSourceNotFoundError) GetMessage() string {
+	return v.NotFoundError.Message
+}
+
+func (v *GetDataSourceCapabilitiesDataSourceNotFoundError) UnmarshalJSON(b []byte) error {
+
+	if string(b) == "null" {
+		return nil
+	}
+
+	var firstPass struct {
+		*GetDataSourceCapabilitiesDataSourceNotFoundError
+		graphql.NoUnmarshalJSON
+	}
+	firstPass.GetDataSourceCapabilitiesDataSourceNotFoundError = v
+
+	err := json.Unmarshal(b, &firstPass)
+	if err != nil {
+		return err
+	}
+
+	err = json.Unmarshal(
+		b, &v.NotFoundError)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+type __premarshalGetDataSourceCapabilitiesDataSourceNotFoundError struct {
+	Typename *string `json:"__typename"`
+
+	Message string `json:"message"`
+}
+
+func (v *GetDataSourceCapabilitiesDataSourceNotFoundError) MarshalJSON() ([]byte, error) {
+	premarshaled, err := v.__premarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(premarshaled)
+}
+
+func (v *GetDataSourceCapabilitiesDataSourceNotFoundError) __premarshalJSON() (*__premarshalGetDataSourceCapabilitiesDataSourceNotFoundError, error) {
+	var retval __premarshalGetDataSourceCapabilitiesDataSourceNotFoundError
+
+	retval.Typename = v.Typename
+	retval.Message = v.NotFoundError.Message
+	return &retval, nil
+}
+
+// GetDataSourceCapabilitiesDataSourcePermissionDeniedError includes the requested fields of the GraphQL type PermissionDeniedError.
+type GetDataSourceCapabilitiesDataSourcePermissionDeniedError struct {
+	Typename              *string `json:"__typename"`
+	PermissionDeniedError `json:"-"`
+}
+
+// GetTypename returns GetDataSourceCapabilitiesDataSourcePermissionDeniedError.Typename, and is useful for accessing the field via an interface.
+func (v *GetDataSourceCapabilitiesDataSourcePermissionDeniedError) GetTypename() *string {
+	return v.Typename
+}
+
+// GetMessage returns GetDataSourceCapabilitiesDataSourcePermissionDeniedError.Message, and is useful for accessing the field via an interface.
+func (v *GetDataSourceCapabilitiesDataSourcePermissionDeniedError) GetMessage() string {
+	return v.PermissionDeniedError.Message
+}
+
+func (v *GetDataSourceCapabilitiesDataSourcePermissionDeniedError) UnmarshalJSON(b []byte) error {
+
+	if string(b) == "null" {
+		return nil
+	}
+
+	var firstPass struct {
+		*GetDataSourceCapabilitiesDataSourcePermissionDeniedError
+		graphql.NoUnmarshalJSON
+	}
+	firstPass.GetDataSourceCapabilitiesDataSourcePermissionDeniedError = v
+
+	err := json.Unmarshal(b, &firstPass)
+	if err != nil {
+		return err
+	}
+
+	err = json.Unmarshal(
+		b, &v.PermissionDeniedError)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+type __premarshalGetDataSourceCapabilitiesDataSourcePermissionDeniedError struct {
+	Typename *string `json:"__typename"`
+
+	Message string `json:"message"`
+}
+
+func (v *GetDataSourceCapabilitiesDataSourcePermissionDeniedError) MarshalJSON() ([]byte, error) {
+	premarshaled, err := v.__premarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(premarshaled)
+}
+
+func (v *GetDataSourceCapabilitiesDataSourcePermissionDeniedError) __premarshalJSON() (*__premarshalGetDataSourceCapabilitiesDataSourcePermissionDeniedError, error) {
+	var retval __premarshalGetDataSourceCapabilitiesDataSourcePermissionDeniedError
+
+	retval.Typename = v.Typename
+	retval.Message = v.PermissionDeniedError.Message
+	return &retval, nil
+}
+
+// GetDataSourceCapabilitiesResponse is returned by GetDataSourceCapabilities on success.
+type GetDataSourceCapabilitiesResponse struct {
+	DataSource GetDataSourceCapabilitiesDataSourceDataSourceResult `json:"-"`
+}
+
+// GetDataSource returns GetDataSourceCapabilitiesResponse.DataSource, and is useful for accessing the field via an interface.
+func (v *GetDataSourceCapabilitiesResponse) GetDataSource() GetDataSourceCapabilitiesDataSourceDataSourceResult {
+	return v.DataSource
+}
+
+func (v *GetDataSourceCapabilitiesResponse) UnmarshalJSON(b []byte) error {
+
+	if string(b) == "null" {
+		return nil
+	}
+
+	var firstPass struct {
+		*GetDataSourceCapabilitiesResponse
+		DataSource json.RawMessage `json:"dataSource"`
+		graphql.NoUnmarshalJSON
+	}
+	firstPass.GetDataSourceCapabilitiesResponse = v
+
+	err := json.Unmarshal(b, &firstPass)
+	if err != nil {
+		return err
+	}
+
+	{
+		dst := &v.DataSource
+		src := firstPass.DataSource
+		if len(src) != 0 && string(src) != "null" {
+			err = __unmarshalGetDataSourceCapabilitiesDataSourceDataSourceResult(
+				src, dst)
+			if err != nil {
+				return fmt.Errorf(
+					"unable to unmarshal GetDataSourceCapabilitiesResponse.DataSource: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+type __premarshalGetDataSourceCapabilitiesResponse struct {
+	DataSource json.RawMessage `json:"dataSource"`
+}
+
+func (v *GetDataSourceCapabilitiesResponse) MarshalJSON() ([]byte, error) {
+	premarshaled, err := v.__premarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(premarshaled)
+}
+
+func (v *GetDataSourceCapabilitiesResponse) __premarshalJSON() (*__premarshalGetDataSourceCapabilitiesResponse, error) {
+	var retval __premarshalGetDataSourceCapabilitiesResponse
+
+	{
+
+		dst := &retval.DataSource
+		src := v.DataSource
+		var err error
+		*dst, err = __marshalGetDataSourceCapabilitiesDataSourceDataSourceResult(
+			&src)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"unable to marshal GetDataSourceCapabilitiesResponse.DataSource: %w", err)
+		}
+	}
+	return &retval, nil
+}
+
 // GetDataSourceDataSource includes the requested fields of the GraphQL type DataSource.
 type GetDataSourceDataSource struct {
 	Typename   *string `json:"__typename"`
@@ -25966,6 +27028,41 @@ func (v *GetGrantCategoryGrantCategory) GetAllowedWhatItems() GrantCategoryDetai
 	return v.GrantCategoryDetails.AllowedWhatItems
 }
 
+// GetCanRequestAccess returns GetGrantCategoryGrantCategory.CanRequestAccess, and is useful for accessing the field via an interface.
+func (v *GetGrantCategoryGrantCategory) GetCanRequestAccess() bool {
+	return v.GrantCategoryDetails.CanRequestAccess
+}
+
+// GetDescriptionMandatory returns GetGrantCategoryGrantCategory.DescriptionMandatory, and is useful for accessing the field via an interface.
+func (v *GetGrantCategoryGrantCategory) GetDescriptionMandatory() bool {
+	return v.GrantCategoryDetails.DescriptionMandatory
+}
+
+// GetNameRegEx returns GetGrantCategoryGrantCategory.NameRegEx, and is useful for accessing the field via an interface.
+func (v *GetGrantCategoryGrantCategory) GetNameRegEx() *string {
+	return v.GrantCategoryDetails.NameRegEx
+}
+
+// GetNameRegExMsg returns GetGrantCategoryGrantCategory.NameRegExMsg, and is useful for accessing the field via an interface.
+func (v *GetGrantCategoryGrantCategory) GetNameRegExMsg() *string {
+	return v.GrantCategoryDetails.NameRegExMsg
+}
+
+// GetNamingHintRegEx returns GetGrantCategoryGrantCategory.NamingHintRegEx, and is useful for accessing the field via an interface.
+func (v *GetGrantCategoryGrantCategory) GetNamingHintRegEx() *string {
+	return v.GrantCategoryDetails.NamingHintRegEx
+}
+
+// GetNamingHintRegExMsg returns GetGrantCategoryGrantCategory.NamingHintRegExMsg, and is useful for accessing the field via an interface.
+func (v *GetGrantCategoryGrantCategory) GetNamingHintRegExMsg() *string {
+	return v.GrantCategoryDetails.NamingHintRegExMsg
+}
+
+// GetLocksOnCreate returns GetGrantCategoryGrantCategory.LocksOnCreate, and is useful for accessing the field via an interface.
+func (v *GetGrantCategoryGrantCategory) GetLocksOnCreate() []AccessProviderLock {
+	return v.GrantCategoryDetails.LocksOnCreate
+}
+
 func (v *GetGrantCategoryGrantCategory) UnmarshalJSON(b []byte) error {
 
 	if string(b) == "null" {
@@ -26021,6 +27118,20 @@ type __premarshalGetGrantCategoryGrantCategory struct {
 	AllowedWhoItems GrantCategoryDetailsAllowedWhoItemsGrantCategoryAllowedWhoItems `json:"allowedWhoItems"`
 
 	AllowedWhatItems GrantCategoryDetailsAllowedWhatItemsGrantCategoryAllowedWhatItems `json:"allowedWhatItems"`
+
+	CanRequestAccess bool `json:"canRequestAccess"`
+
+	DescriptionMandatory bool `json:"descriptionMandatory"`
+
+	NameRegEx *string `json:"nameRegEx"`
+
+	NameRegExMsg *string `json:"nameRegExMsg"`
+
+	NamingHintRegEx *string `json:"namingHintRegEx"`
+
+	NamingHintRegExMsg *string `json:"namingHintRegExMsg"`
+
+	LocksOnCreate []AccessProviderLock `json:"locksOnCreate"`
 }
 
 func (v *GetGrantCategoryGrantCategory) MarshalJSON() ([]byte, error) {
@@ -26049,6 +27160,13 @@ func (v *GetGrantCategoryGrantCategory) __premarshalJSON() (*__premarshalGetGran
 	retval.DefaultTypePerDataSource = v.GrantCategoryDetails.DefaultTypePerDataSource
 	retval.AllowedWhoItems = v.GrantCategoryDetails.AllowedWhoItems
 	retval.AllowedWhatItems = v.GrantCategoryDetails.AllowedWhatItems
+	retval.CanRequestAccess = v.GrantCategoryDetails.CanRequestAccess
+	retval.DescriptionMandatory = v.GrantCategoryDetails.DescriptionMandatory
+	retval.NameRegEx = v.GrantCategoryDetails.NameRegEx
+	retval.NameRegExMsg = v.GrantCategoryDetails.NameRegExMsg
+	retval.NamingHintRegEx = v.GrantCategoryDetails.NamingHintRegEx
+	retval.NamingHintRegExMsg = v.GrantCategoryDetails.NamingHintRegExMsg
+	retval.LocksOnCreate = v.GrantCategoryDetails.LocksOnCreate
 	return &retval, nil
 }
 
@@ -28198,6 +29316,13 @@ type GrantCategoryDetails struct {
 	DefaultTypePerDataSource []GrantCategoryDetailsDefaultTypePerDataSourceGrantCategoryTypeForDataSource `json:"defaultTypePerDataSource"`
 	AllowedWhoItems          GrantCategoryDetailsAllowedWhoItemsGrantCategoryAllowedWhoItems              `json:"allowedWhoItems"`
 	AllowedWhatItems         GrantCategoryDetailsAllowedWhatItemsGrantCategoryAllowedWhatItems            `json:"allowedWhatItems"`
+	CanRequestAccess         bool                                                                         `json:"canRequestAccess"`
+	DescriptionMandatory     bool                                                                         `json:"descriptionMandatory"`
+	NameRegEx                *string                                                                      `json:"nameRegEx"`
+	NameRegExMsg             *string                                                                      `json:"nameRegExMsg"`
+	NamingHintRegEx          *string                                                                      `json:"namingHintRegEx"`
+	NamingHintRegExMsg       *string                                                                      `json:"namingHintRegExMsg"`
+	LocksOnCreate            []AccessProviderLock                                                         `json:"locksOnCreate"`
 }
 
 // GetId returns GrantCategoryDetails.Id, and is useful for accessing the field via an interface.
@@ -28248,6 +29373,27 @@ func (v *GrantCategoryDetails) GetAllowedWhatItems() GrantCategoryDetailsAllowed
 	return v.AllowedWhatItems
 }
 
+// GetCanRequestAccess returns GrantCategoryDetails.CanRequestAccess, and is useful for accessing the field via an interface.
+func (v *GrantCategoryDetails) GetCanRequestAccess() bool { return v.CanRequestAccess }
+
+// GetDescriptionMandatory returns GrantCategoryDetails.DescriptionMandatory, and is useful for accessing the field via an interface.
+func (v *GrantCategoryDetails) GetDescriptionMandatory() bool { return v.DescriptionMandatory }
+
+// GetNameRegEx returns GrantCategoryDetails.NameRegEx, and is useful for accessing the field via an interface.
+func (v *GrantCategoryDetails) GetNameRegEx() *string { return v.NameRegEx }
+
+// GetNameRegExMsg returns GrantCategoryDetails.NameRegExMsg, and is useful for accessing the field via an interface.
+func (v *GrantCategoryDetails) GetNameRegExMsg() *string { return v.NameRegExMsg }
+
+// GetNamingHintRegEx returns GrantCategoryDetails.NamingHintRegEx, and is useful for accessing the field via an interface.
+func (v *GrantCategoryDetails) GetNamingHintRegEx() *string { return v.NamingHintRegEx }
+
+// GetNamingHintRegExMsg returns GrantCategoryDetails.NamingHintRegExMsg, and is useful for accessing the field via an interface.
+func (v *GrantCategoryDetails) GetNamingHintRegExMsg() *string { return v.NamingHintRegExMsg }
+
+// GetLocksOnCreate returns GrantCategoryDetails.LocksOnCreate, and is useful for accessing the field via an interface.
+func (v *GrantCategoryDetails) GetLocksOnCreate() []AccessProviderLock { return v.LocksOnCreate }
+
 // GrantCategoryDetailsAllowedWhatItemsGrantCategoryAllowedWhatItems includes the requested fields of the GraphQL type GrantCategoryAllowedWhatItems.
 type GrantCategoryDetailsAllowedWhatItemsGrantCategoryAllowedWhatItems struct {
 	GrantCategoryAllowedWhatItems `json:"-"`
@@ -33448,6 +34594,41 @@ func (v *ListGrantCategoriesGrantCategoriesGrantCategory) GetAllowedWhatItems()
 	return v.GrantCategoryDetails.AllowedWhatItems
 }
 
+// GetCanRequestAccess returns ListGrantCategoriesGrantCategoriesGrantCategory.CanRequestAccess, and is useful for accessing the field via an interface.
+func (v *ListGrantCategoriesGrantCategoriesGrantCategory) GetCanRequestAccess() bool {
+	return v.GrantCategoryDetails.CanRequestAccess
+}
+
+// GetDescriptionMandatory returns ListGrantCategoriesGrantCategoriesGrantCategory.DescriptionMandatory, and is useful for accessing the field via an interface.
+func (v *ListGrantCategoriesGrantCategoriesGrantCategory) GetDescriptionMandatory() bool {
+	return v.GrantCategoryDetails.DescriptionMandatory
+}
+
+// GetNameRegEx returns ListGrantCategoriesGrantCategoriesGrantCategory.NameRegEx, and is useful for accessing the field via an interface.
+func (v *ListGrantCategoriesGrantCategoriesGrantCategory) GetNameRegEx() *string {
+	return v.GrantCategoryDetails.NameRegEx
+}
+
+// GetNameRegExMsg returns ListGrantCategoriesGrantCategoriesGrantCategory.NameRegExMsg, and is useful for accessing the field via an interface.
+func (v *ListGrantCategoriesGrantCategoriesGrantCategory) GetNameRegExMsg() *string {
+	return v.GrantCategoryDetails.NameRegExMsg
+}
+
+// GetNamingHintRegEx returns ListGrantCategoriesGrantCategoriesGrantCategory.NamingHintRegEx, and is useful for accessing the field via an interface.
+func (v *ListGrantCategoriesGrantCategoriesGrantCategory) GetNamingHintRegEx() *string {
+	return v.GrantCategoryDetails.NamingHintRegEx
+}
+
+// GetNamingHintRegExMsg returns ListGrantCategoriesGrantCategoriesGrantCategory.NamingHintRegExMsg, and is useful for accessing the field via an interface.
+func (v *ListGrantCategoriesGrantCategoriesGrantCategory) GetNamingHintRegExMsg() *string {
+	return v.GrantCategoryDetails.NamingHintRegExMsg
+}
+
+// GetLocksOnCreate returns ListGrantCategoriesGrantCategoriesGrantCategory.LocksOnCreate, and is useful for accessing the field via an interface.
+func (v *ListGrantCategoriesGrantCategoriesGrantCategory) GetLocksOnCreate() []AccessProviderLock {
+	return v.GrantCategoryDetails.LocksOnCreate
+}
+
 func (v *ListGrantCategoriesGrantCategoriesGrantCategory) UnmarshalJSON(b []byte) error {
 
 	if string(b) == "null" {
@@ -33501,6 +34682,20 @@ type __premarshalListGrantCategoriesGrantCategoriesGrantCategory struct {
 	AllowedWhoItems GrantCategoryDetailsAllowedWhoItemsGrantCategoryAllowedWhoItems `json:"allowedWhoItems"`
 
 	AllowedWhatItems GrantCategoryDetailsAllowedWhatItemsGrantCategoryAllowedWhatItems `json:"allowedWhatItems"`
+
+	CanRequestAccess bool `json:"canRequestAccess"`
+
+	DescriptionMandatory bool `json:"descriptionMandatory"`
+
+	NameRegEx *string `json:"nameRegEx"`
+
+	NameRegExMsg *string `json:"nameRegExMsg"`
+
+	NamingHintRegEx *string `json:"namingHintRegEx"`
+
+	NamingHintRegExMsg *string `json:"namingHintRegExMsg"`
+
+	LocksOnCreate []AccessProviderLock `json:"locksOnCreate"`
 }
 
 func (v *ListGrantCategoriesGrantCategoriesGrantCategory) MarshalJSON() ([]byte, error) {
@@ -33528,6 +34723,13 @@ func (v *ListGrantCategoriesGrantCategoriesGrantCategory) __premarshalJSON() (*_
 	retval.DefaultTypePerDataSource = v.GrantCategoryDetails.DefaultTypePerDataSource
 	retval.AllowedWhoItems = v.GrantCategoryDetails.AllowedWhoItems
 	retval.AllowedWhatItems = v.GrantCategoryDetails.AllowedWhatItems
+	retval.CanRequestAccess = v.GrantCategoryDetails.CanRequestAccess
+	retval.DescriptionMandatory = v.GrantCategoryDetails.DescriptionMandatory
+	retval.NameRegEx = v.GrantCategoryDetails.NameRegEx
+	retval.NameRegExMsg = v.GrantCategoryDetails.NameRegExMsg
+	retval.NamingHintRegEx = v.GrantCategoryDetails.NamingHintRegEx
+	retval.NamingHintRegExMsg = v.GrantCategoryDetails.NamingHintRegExMsg
+	retval.LocksOnCreate = v.GrantCategoryDetails.LocksOnCreate
 	return &retval, nil
 }
 
@@ -36062,6 +37264,429 @@ func (v *MaskingMetadataMaskTypesMaskType) __premarshalJSON() (*__premarshalMask
 	return &retval, nil
 }
 
+// MoveDataSourceResponse is returned by MoveDataSource on success.
+type MoveDataSourceResponse struct {
+	UpdateDataSource MoveDataSourceUpdateDataSourceDataSourceResult `json:"-"`
+}
+
+// GetUpdateDataSource returns MoveDataSourceResponse.UpdateDataSource, and is useful for accessing the field via an interface.
+func (v *MoveDataSourceResponse) GetUpdateDataSource() MoveDataSourceUpdateDataSourceDataSourceResult {
+	return v.UpdateDataSource
+}
+
+func (v *MoveDataSourceResponse) UnmarshalJSON(b []byte) error {
+
+	if string(b) == "null" {
+		return nil
+	}
+
+	var firstPass struct {
+		*MoveDataSourceResponse
+		UpdateDataSource json.RawMessage `json:"updateDataSource"`
+		graphql.NoUnmarshalJSON
+	}
+	firstPass.MoveDataSourceResponse = v
+
+	err := json.Unmarshal(b, &firstPass)
+	if err != nil {
+		return err
+	}
+
+	{
+		dst := &v.UpdateDataSource
+		src := firstPass.UpdateDataSource
+		if len(src) != 0 && string(src) != "null" {
+			err = __unmarshalMoveDataSourceUpdateDataSourceDataSourceResult(
+				src, dst)
+			if err != nil {
+				return fmt.Errorf(
+					"unable to unmarshal MoveDataSourceResponse.UpdateDataSource: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+type __premarshalMoveDataSourceResponse struct {
+	UpdateDataSource json.RawMessage `json:"updateDataSource"`
+}
+
+func (v *MoveDataSourceResponse) MarshalJSON() ([]byte, error) {
+	premarshaled, err := v.__premarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(premarshaled)
+}
+
+func (v *MoveDataSourceResponse) __premarshalJSON() (*__premarshalMoveDataSourceResponse, error) {
+	var retval __premarshalMoveDataSourceResponse
+
+	{
+
+		dst := &retval.UpdateDataSource
+		src := v.UpdateDataSource
+		var err error
+		*dst, err = __marshalMoveDataSourceUpdateDataSourceDataSourceResult(
+			&src)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"unable to marshal MoveDataSourceResponse.UpdateDataSource: %w", err)
+		}
+	}
+	return &retval, nil
+}
+
+// MoveDataSourceUpdateDataSource includes the requested fields of the GraphQL type DataSource.
+type MoveDataSourceUpdateDataSource struct {
+	Typename   *string `json:"__typename"`
+	DataSource `json:"-"`
+}
+
+// GetTypename returns MoveDataSourceUpdateDataSource.Typename, and is useful for accessing the field via an interface.
+func (v *MoveDataSourceUpdateDataSource) GetTypename() *string { return v.Typename }
+
+// GetId returns MoveDataSourceUpdateDataSource.Id, and is useful for accessing the field via an interface.
+func (v *MoveDataSourceUpdateDataSource) GetId() string { return v.DataSource.Id }
+
+// GetName returns MoveDataSourceUpdateDataSource.Name, and is useful for accessing the field via an interface.
+func (v *MoveDataSourceUpdateDataSource) GetName() string { return v.DataSource.Name }
+
+// GetType returns MoveDataSourceUpdateDataSource.Type, and is useful for accessing the field via an interface.
+func (v *MoveDataSourceUpdateDataSource) GetType() string { return v.DataSource.Type }
+
+// GetDescription returns MoveDataSourceUpdateDataSource.Description, and is useful for accessing the field via an interface.
+func (v *MoveDataSourceUpdateDataSource) GetDescription() string { return v.DataSource.Description }
+
+// GetCreatedAt returns MoveDataSourceUpdateDataSource.CreatedAt, and is useful for accessing the field via an interface.
+func (v *MoveDataSourceUpdateDataSource) GetCreatedAt() time.Time { return v.DataSource.CreatedAt }
+
+// GetModifiedAt returns MoveDataSourceUpdateDataSource.ModifiedAt, and is useful for accessing the field via an interface.
+func (v *MoveDataSourceUpdateDataSource) GetModifiedAt() time.Time { return v.DataSource.ModifiedAt }
+
+// GetSyncMethod returns MoveDataSourceUpdateDataSource.SyncMethod, and is useful for accessing the field via an interface.
+func (v *MoveDataSourceUpdateDataSource) GetSyncMethod() DataSourceSyncMethod {
+	return v.DataSource.SyncMethod
+}
+
+// GetParent returns MoveDataSourceUpdateDataSource.Parent, and is useful for accessing the field via an interface.
+func (v *MoveDataSourceUpdateDataSource) GetParent() *DataSourceParentDataSource {
+	return v.DataSource.Parent
+}
+
+func (v *MoveDataSourceUpdateDataSource) UnmarshalJSON(b []byte) error {
+
+	if string(b) == "null" {
+		return nil
+	}
+
+	var firstPass struct {
+		*MoveDataSourceUpdateDataSource
+		graphql.NoUnmarshalJSON
+	}
+	firstPass.MoveDataSourceUpdateDataSource = v
+
+	err := json.Unmarshal(b, &firstPass)
+	if err != nil {
+		return err
+	}
+
+	err = json.Unmarshal(
+		b, &v.DataSource)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+type __premarshalMoveDataSourceUpdateDataSource struct {
+	Typename *string `json:"__typename"`
+
+	Id string `json:"id"`
+
+	Name string `json:"name"`
+
+	Type string `json:"type"`
+
+	Description string `json:"description"`
+
+	CreatedAt time.Time `json:"createdAt"`
+
+	ModifiedAt time.Time `json:"modifiedAt"`
+
+	SyncMethod DataSourceSyncMethod `json:"syncMethod"`
+
+	Parent *DataSourceParentDataSource `json:"parent"`
+}
+
+func (v *MoveDataSourceUpdateDataSource) MarshalJSON() ([]byte, error) {
+	premarshaled, err := v.__premarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(premarshaled)
+}
+
+func (v *MoveDataSourceUpdateDataSource) __premarshalJSON() (*__premarshalMoveDataSourceUpdateDataSource, error) {
+	var retval __premarshalMoveDataSourceUpdateDataSource
+
+	retval.Typename = v.Typename
+	retval.Id = v.DataSource.Id
+	retval.Name = v.DataSource.Name
+	retval.Type = v.DataSource.Type
+	retval.Description = v.DataSource.Description
+	retval.CreatedAt = v.DataSource.CreatedAt
+	retval.ModifiedAt = v.DataSource.ModifiedAt
+	retval.SyncMethod = v.DataSource.SyncMethod
+	retval.Parent = v.DataSource.Parent
+	return &retval, nil
+}
+
+// MoveDataSourceUpdateDataSourceDataSourceResult includes the requested fields of the GraphQL interface DataSourceResult.
+//
+// MoveDataSourceUpdateDataSourceDataSourceResult is implemented by the following types:
+// MoveDataSourceUpdateDataSource
+// MoveDataSourceUpdateDataSourceInvalidInputError
+// MoveDataSourceUpdateDataSourceNotFoundError
+// MoveDataSourceUpdateDataSourcePermissionDeniedError
+type MoveDataSourceUpdateDataSourceDataSourceResult interface {
+	implementsGraphQLInterfaceMoveDataSourceUpdateDataSourceDataSourceResult()
+	// GetTypename returns the receiver's concrete GraphQL type-name (see interface doc for possible values).
+	GetTypename() *string
+}
+
+func (v *MoveDataSourceUpdateDataSource) implementsGraphQLInterfaceMoveDataSourceUpdateDataSourceDataSourceResult() {
+}
+func (v *MoveDataSourceUpdateDataSourceInvalidInputError) implementsGraphQLInterfaceMoveDataSourceUpdateDataSourceDataSourceResult() {
+}
+func (v *MoveDataSourceUpdateDataSourceNotFoundError) implementsGraphQLInterfaceMoveDataSourceUpdateDataSourceDataSourceResult() {
+}
+func (v *MoveDataSourceUpdateDataSourcePermissionDeniedError) implementsGraphQLInterfaceMoveDataSourceUpdateDataSourceDataSourceResult() {
+}
+
+func __unmarshalMoveDataSourceUpdateDataSourceDataSourceResult(b []byte, v *MoveDataSourceUpdateDataSourceDataSourceResult) error {
+	if string(b) == "null" {
+		return nil
+	}
+
+	var tn struct {
+		TypeName string `json:"__typename"`
+	}
+	err := json.Unmarshal(b, &tn)
+	if err != nil {
+		return err
+	}
+
+	switch tn.TypeName {
+	case "DataSource":
+		*v = new(MoveDataSourceUpdateDataSource)
+		return json.Unmarshal(b, *v)
+	case "InvalidInputError":
+		*v = new(MoveDataSourceUpdateDataSourceInvalidInputError)
+		return json.Unmarshal(b, *v)
+	case "NotFoundError":
+		*v = new(MoveDataSourceUpdateDataSourceNotFoundError)
+		return json.Unmarshal(b, *v)
+	case "PermissionDeniedError":
+		*v = new(MoveDataSourceUpdateDataSourcePermissionDeniedError)
+		return json.Unmarshal(b, *v)
+	case "":
+		return fmt.Errorf(
+			"response was missing DataSourceResult.__typename")
+	default:
+		return fmt.Errorf(
+			`unexpected concrete type for MoveDataSourceUpdateDataSourceDataSourceResult: "%v"`, tn.TypeName)
+	}
+}
+
+func __marshalMoveDataSourceUpdateDataSourceDataSourceResult(v *MoveDataSourceUpdateDataSourceDataSourceResult) ([]byte, error) {
+
+	var typename string
+	switch v := (*v).(type) {
+	case *MoveDataSourceUpdateDataSource:
+		typename = "DataSource"
+
+		premarshaled, err := v.__premarshalJSON()
+		if err != nil {
+			return nil, err
+		}
+		result := struct {
+			TypeName string `json:"__typename"`
+			*__premarshalMoveDataSourceUpdateDataSource
+		}{typename, premarshaled}
+		return json.Marshal(result)
+	case *MoveDataSourceUpdateDataSourceInvalidInputError:
+		typename = "InvalidInputError"
+
+		result := struct {
+			TypeName string `json:"__typename"`
+			*MoveDataSourceUpdateDataSourceInvalidInputError
+		}{typename, v}
+		return json.Marshal(result)
+	case *MoveDataSourceUpdateDataSourceNotFoundError:
+		typename = "NotFoundError"
+
+		premarshaled, err := v.__premarshalJSON()
+		if err != nil {
+			return nil, err
+		}
+		result := struct {
+			TypeName string `json:"__typename"`
+			*__premarshalMoveDataSourceUpdateDataSourceNotFoundError
+		}{typename, premarshaled}
+		return json.Marshal(result)
+	case *MoveDataSourceUpdateDataSourcePermissionDeniedError:
+		typename = "PermissionDeniedError"
+
+		premarshaled, err := v.__premarshalJSON()
+		if err != nil {
+			return nil, err
+		}
+		result := struct {
+			TypeName string `json:"__typename"`
+			*__premarshalMoveDataSourceUpdateDataSourcePermissionDeniedError
+		}{typename, premarshaled}
+		return json.Marshal(result)
+	case nil:
+		return []byte("null"), nil
+	default:
+		return nil, fmt.Errorf(
+			`unexpected concrete type for MoveDataSourceUpdateDataSourceDataSourceResult: "%T"`, v)
+	}
+}
+
+// MoveDataSourceUpdateDataSourceInvalidInputError includes the requested fields of the GraphQL type InvalidInputError.
+type MoveDataSourceUpdateDataSourceInvalidInputError struct {
+	Typename *string `json:"__typename"`
+}
+
+// GetTypename returns MoveDataSourceUpdateDataSourceInvalidInputError.Typename, and is useful for accessing the field via an interface.
+func (v *MoveDataSourceUpdateDataSourceInvalidInputError) GetTypename() *string { return v.Typename }
+
+// MoveDataSourceUpdateDataSourceNotFoundError includes the requested fields of the GraphQL type NotFoundError.
+type MoveDataSourceUpdateDataSourceNotFoundError struct {
+	Typename      *string `json:"__typename"`
+	NotFoundError `json:"-"`
+}
+
+// GetTypename returns MoveDataSourceUpdateDataSourceNotFoundError.Typename, and is useful for accessing the field via an interface.
+func (v *MoveDataSourceUpdateDataSourceNotFoundError) GetTypename() *string { return v.Typename }
+
+// GetMessage returns MoveDataSourceUpdateDataSourceNotFoundError.Message, and is useful for accessing the field via an interface.
+func (v *MoveDataSourceUpdateDataSourceNotFoundError) GetMessage() string {
+	return v.NotFoundError.Message
+}
+
+func (v *MoveDataSourceUpdateDataSourceNotFoundError) UnmarshalJSON(b []byte) error {
+
+	if string(b) == "null" {
+		return nil
+	}
+
+	var firstPass struct {
+		*MoveDataSourceUpdateDataSourceNotFoundError
+		graphql.NoUnmarshalJSON
+	}
+	firstPass.MoveDataSourceUpdateDataSourceNotFoundError = v
+
+	err := json.Unmarshal(b, &firstPass)
+	if err != nil {
+		return err
+	}
+
+	err = json.Unmarshal(
+		b, &v.NotFoundError)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+type __premarshalMoveDataSourceUpdateDataSourceNotFoundError struct {
+	Typename *string `json:"__typename"`
+
+	Message string `json:"message"`
+}
+
+func (v *MoveDataSourceUpdateDataSourceNotFoundError) MarshalJSON() ([]byte, error) {
+	premarshaled, err := v.__premarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(premarshaled)
+}
+
+func (v *MoveDataSourceUpdateDataSourceNotFoundError) __premarshalJSON() (*__premarshalMoveDataSourceUpdateDataSourceNotFoundError, error) {
+	var retval __premarshalMoveDataSourceUpdateDataSourceNotFoundError
+
+	retval.Typename = v.Typename
+	retval.Message = v.NotFoundError.Message
+	return &retval, nil
+}
+
+// MoveDataSourceUpdateDataSourcePermissionDeniedError includes the requested fields of the GraphQL type PermissionDeniedError.
+type MoveDataSourceUpdateDataSourcePermissionDeniedError struct {
+	Typename              *string `json:"__typename"`
+	PermissionDeniedError `json:"-"`
+}
+
+// GetTypename returns MoveDataSourceUpdateDataSourcePermissionDeniedError.Typename, and is useful for accessing the field via an interface.
+func (v *MoveDataSourceUpdateDataSourcePermissionDeniedError) GetTypename() *string {
+	return v.Typename
+}
+
+// GetMessage returns MoveDataSourceUpdateDataSourcePermissionDeniedError.Message, and is useful for accessing the field via an interface.
+func (v *MoveDataSourceUpdateDataSourcePermissionDeniedError) GetMessage() string {
+	return v.PermissionDeniedError.Message
+}
+
+func (v *MoveDataSourceUpdateDataSourcePermissionDeniedError) UnmarshalJSON(b []byte) error {
+
+	if string(b) == "null" {
+		return nil
+	}
+
+	var firstPass struct {
+		*MoveDataSourceUpdateDataSourcePermissionDeniedError
+		graphql.NoUnmarshalJSON
+	}
+	firstPass.MoveDataSourceUpdateDataSourcePermissionDeniedError = v
+
+	err := json.Unmarshal(b, &firstPass)
+	if err != nil {
+		return err
+	}
+
+	err = json.Unmarshal(
+		b, &v.PermissionDeniedError)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+type __premarshalMoveDataSourceUpdateDataSourcePermissionDeniedError struct {
+	Typename *string `json:"__typename"`
+
+	Message string `json:"message"`
+}
+
+func (v *MoveDataSourceUpdateDataSourcePermissionDeniedError) MarshalJSON() ([]byte, error) {
+	premarshaled, err := v.__premarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(premarshaled)
+}
+
+func (v *MoveDataSourceUpdateDataSourcePermissionDeniedError) __premarshalJSON() (*__premarshalMoveDataSourceUpdateDataSourcePermissionDeniedError, error) {
+	var retval __premarshalMoveDataSourceUpdateDataSourcePermissionDeniedError
+
+	retval.Typename = v.Typename
+	retval.Message = v.PermissionDeniedError.Message
+	return &retval, nil
+}
+
 // NotFoundError includes the GraphQL fields of NotFoundError requested by the fragment NotFoundError.
 type NotFoundError struct {
 	Message string `json:"message"`
@@ -41363,6 +42988,18 @@ var AllSyncStatus = []SyncStatus{
 	SyncStatusSynced,
 }
 
+// Tag includes the GraphQL fields of Tag requested by the fragment Tag.
+type Tag struct {
+	Key         string `json:"key"`
+	StringValue string `json:"stringValue"`
+}
+
+// GetKey returns Tag.Key, and is useful for accessing the field via an interface.
+func (v *Tag) GetKey() string { return v.Key }
+
+// GetStringValue returns Tag.StringValue, and is useful for accessing the field via an interface.
+func (v *Tag) GetStringValue() string { return v.StringValue }
+
 type TagFilter struct {
 	Key         *string `json:"key,omitempty"`
 	StringValue *string `json:"stringValue,omitempty"`
@@ -44761,6 +46398,41 @@ func (v *UpdateGrantCategoryUpdateGrantCategory) GetAllowedWhatItems() GrantCate
 	return v.GrantCategoryDetails.AllowedWhatItems
 }
 
+// GetCanRequestAccess returns UpdateGrantCategoryUpdateGrantCategory.CanRequestAccess, and is useful for accessing the field via an interface.
+func (v *UpdateGrantCategoryUpdateGrantCategory) GetCanRequestAccess() bool {
+	return v.GrantCategoryDetails.CanRequestAccess
+}
+
+// GetDescriptionMandatory returns UpdateGrantCategoryUpdateGrantCategory.DescriptionMandatory, and is useful for accessing the field via an interface.
+func (v *UpdateGrantCategoryUpdateGrantCategory) GetDescriptionMandatory() bool {
+	return v.GrantCategoryDetails.DescriptionMandatory
+}
+
+// GetNameRegEx returns UpdateGrantCategoryUpdateGrantCategory.NameRegEx, and is useful for accessing the field via an interface.
+func (v *UpdateGrantCategoryUpdateGrantCategory) GetNameRegEx() *string {
+	return v.GrantCategoryDetails.NameRegEx
+}
+
+// GetNameRegExMsg returns UpdateGrantCategoryUpdateGrantCategory.NameRegExMsg, and is useful for accessing the field via an interface.
+func (v *UpdateGrantCategoryUpdateGrantCategory) GetNameRegExMsg() *string {
+	return v.GrantCategoryDetails.NameRegExMsg
+}
+
+// GetNamingHintRegEx returns UpdateGrantCategoryUpdateGrantCategory.NamingHintRegEx, and is useful for accessing the field via an interface.
+func (v *UpdateGrantCategoryUpdateGrantCategory) GetNamingHintRegEx() *string {
+	return v.GrantCategoryDetails.NamingHintRegEx
+}
+
+// GetNamingHintRegExMsg returns UpdateGrantCategoryUpdateGrantCategory.NamingHintRegExMsg, and is useful for accessing the field via an interface.
+func (v *UpdateGrantCategoryUpdateGrantCategory) GetNamingHintRegExMsg() *string {
+	return v.GrantCategoryDetails.NamingHintRegExMsg
+}
+
+// GetLocksOnCreate returns UpdateGrantCategoryUpdateGrantCategory.LocksOnCreate, and is useful for accessing the field via an interface.
+func (v *UpdateGrantCategoryUpdateGrantCategory) GetLocksOnCreate() []AccessProviderLock {
+	return v.GrantCategoryDetails.LocksOnCreate
+}
+
 func (v *UpdateGrantCategoryUpdateGrantCategory) UnmarshalJSON(b []byte) error {
 
 	if string(b) == "null" {
@@ -44816,6 +46488,20 @@ type __premarshalUpdateGrantCategoryUpdateGrantCategory struct {
 	AllowedWhoItems GrantCategoryDetailsAllowedWhoItemsGrantCategoryAllowedWhoItems `json:"allowedWhoItems"`
 
 	AllowedWhatItems GrantCategoryDetailsAllowedWhatItemsGrantCategoryAllowedWhatItems `json:"allowedWhatItems"`
+
+	CanRequestAccess bool `json:"canRequestAccess"`
+
+	DescriptionMandatory bool `json:"descriptionMandatory"`
+
+	NameRegEx *string `json:"nameRegEx"`
+
+	NameRegExMsg *string `json:"nameRegExMsg"`
+
+	NamingHintRegEx *string `json:"namingHintRegEx"`
+
+	NamingHintRegExMsg *string `json:"namingHintRegExMsg"`
+
+	LocksOnCreate []AccessProviderLock `json:"locksOnCreate"`
 }
 
 func (v *UpdateGrantCategoryUpdateGrantCategory) MarshalJSON() ([]byte, error) {
@@ -44844,6 +46530,13 @@ func (v *UpdateGrantCategoryUpdateGrantCategory) __premarshalJSON() (*__premarsh
 	retval.DefaultTypePerDataSource = v.GrantCategoryDetails.DefaultTypePerDataSource
 	retval.AllowedWhoItems = v.GrantCategoryDetails.AllowedWhoItems
 	retval.AllowedWhatItems = v.GrantCategoryDetails.AllowedWhatItems
+	retval.CanRequestAccess = v.GrantCategoryDetails.CanRequestAccess
+	retval.DescriptionMandatory = v.GrantCategoryDetails.DescriptionMandatory
+	retval.NameRegEx = v.GrantCategoryDetails.NameRegEx
+	retval.NameRegExMsg = v.GrantCategoryDetails.NameRegExMsg
+	retval.NamingHintRegEx = v.GrantCategoryDetails.NamingHintRegEx
+	retval.NamingHintRegExMsg = v.GrantCategoryDetails.NamingHintRegExMsg
+	retval.LocksOnCreate = v.GrantCategoryDetails.LocksOnCreate
 	return &retval, nil
 }
 
@@ -48708,6 +50401,14 @@ type __GetAccessProviderInput struct {
 // GetId returns __GetAccessProviderInput.Id, and is useful for accessing the field via an interface.
 func (v *__GetAccessProviderInput) GetId() string { return v.Id }
 
+// __GetAccessProviderTagsInput is used internally by genqlient
+type __GetAccessProviderTagsInput struct {
+	Id string `json:"id"`
+}
+
+// GetId returns __GetAccessProviderTagsInput.Id, and is useful for accessing the field via an interface.
+func (v *__GetAccessProviderTagsInput) GetId() string { return v.Id }
+
 // __GetAccessProviderWhatAccessProvidersInput is used internally by genqlient
 type __GetAccessProviderWhatAccessProvidersInput struct {
 	Id     string                                       `json:"id"`
@@ -48800,6 +50501,22 @@ type __GetDataObjectInput struct {
 // GetDataObjectId returns __GetDataObjectInput.DataObjectId, and is useful for accessing the field via an interface.
 func (v *__GetDataObjectInput) GetDataObjectId() string { return v.DataObjectId }
 
+// __GetDataObjectTagsInput is used internally by genqlient
+type __GetDataObjectTagsInput struct {
+	DataObjectId string `json:"dataObjectId"`
+}
+
+// GetDataObjectId returns __GetDataObjectTagsInput.DataObjectId, and is useful for accessing the field via an interface.
+func (v *__GetDataObjectTagsInput) GetDataObjectId() string { return v.DataObjectId }
+
+// __GetDataSourceCapabilitiesInput is used internally by genqlient
+type __GetDataSourceCapabilitiesInput struct {
+	Id string `json:"id"`
+}
+
+// GetId returns __GetDataSourceCapabilitiesInput.Id, and is useful for accessing the field via an interface.
+func (v *__GetDataSourceCapabilitiesInput) GetId() string { return v.Id }
+
 // __GetDataSourceInput is used internally by genqlient
 type __GetDataSourceInput struct {
 	Id string `json:"id"`
@@ -49176,6 +50893,18 @@ func (v *__ListRolesInput) GetFilter() *RoleFilterInput { return v.Filter }
 // GetOrder returns __ListRolesInput.Order, and is useful for accessing the field via an interface.
 func (v *__ListRolesInput) GetOrder() []RoleOrderByInput { return v.Order }
 
+// __MoveDataSourceInput is used internally by genqlient
+type __MoveDataSourceInput struct {
+	Id     string  `json:"id"`
+	Parent *string `json:"parent"`
+}
+
+// GetId returns __MoveDataSourceInput.Id, and is useful for accessing the field via an interface.
+func (v *__MoveDataSourceInput) GetId() string { return v.Id }
+
+// GetParent returns __MoveDataSourceInput.Parent, and is useful for accessing the field via an interface.
+func (v *__MoveDataSourceInput) GetParent() *string { return v.Parent }
+
 // __RemoveAsRaitoUserInput is used internally by genqlient
 type __RemoveAsRaitoUserInput struct {
 	UId string `json:"uId"`
@@ -50115,6 +51844,13 @@ fragment GrantCategoryDetails on GrantCategory {
 	allowedWhatItems {
 		... GrantCategoryAllowedWhatItems
 	}
+	canRequestAccess
+	descriptionMandatory
+	nameRegEx
+	nameRegExMsg
+	namingHintRegEx
+	namingHintRegExMsg
+	locksOnCreate
 }
 fragment PermissionDeniedError on PermissionDeniedError {
 	message
@@ -50736,195 +52472,332 @@ fragment MaskType on MaskType {
 }
 `
 
-func DeleteAccessProvider(
-	ctx_ context.Context,
-	client_ graphql.Client,
-	id string,
-	overrideLocks *bool,
-) (data_ *DeleteAccessProviderResponse, err_ error) {
-	req_ := &graphql.Request{
-		OpName: "DeleteAccessProvider",
-		Query:  DeleteAccessProvider_Operation,
-		Variables: &__DeleteAccessProviderInput{
-			Id:            id,
-			OverrideLocks: overrideLocks,
-		},
-	}
-
-	data_ = &DeleteAccessProviderResponse{}
-	resp_ := &graphql.Response{Data: data_}
-
-	err_ = client_.MakeRequest(
-		ctx_,
-		req_,
-		resp_,
-	)
-
-	return data_, err_
-}
-
-// The mutation executed by DeleteDataSource.
-const DeleteDataSource_Operation = `
-mutation DeleteDataSource ($id: ID!) {
-	deleteDataSource(id: $id) {
-		__typename
-		... PermissionDeniedError
-		... on DeleteDataSource {
-			success
-		}
-	}
-}
-fragment PermissionDeniedError on PermissionDeniedError {
-	message
-}
-`
-
-func DeleteDataSource(
-	ctx_ context.Context,
-	client_ graphql.Client,
-	id string,
-) (data_ *DeleteDataSourceResponse, err_ error) {
-	req_ := &graphql.Request{
-		OpName: "DeleteDataSource",
-		Query:  DeleteDataSource_Operation,
-		Variables: &__DeleteDataSourceInput{
-			Id: id,
-		},
-	}
-
-	data_ = &DeleteDataSourceResponse{}
-	resp_ := &graphql.Response{Data: data_}
-
-	err_ = client_.MakeRequest(
-		ctx_,
-		req_,
-		resp_,
-	)
-
-	return data_, err_
-}
-
-// The mutation executed by DeleteGrantCategory.
-const DeleteGrantCategory_Operation = `
-mutation DeleteGrantCategory ($id: ID!) {
-	deleteGrantCategory(id: $id) {
-		__typename
-		... DeleteGrantCategoryResult
-		... PermissionDeniedError
-		... NotFoundError
-		... InvalidInputError
-	}
-}
-fragment DeleteGrantCategoryResult on DeleteGrantCategory {
-	success
-}
-fragment PermissionDeniedError on PermissionDeniedError {
-	message
-}
-fragment NotFoundError on NotFoundError {
-	message
-}
-fragment InvalidInputError on InvalidInputError {
-	message
-}
-`
-
-func DeleteGrantCategory(
-	ctx_ context.Context,
-	client_ graphql.Client,
-	id string,
-) (data_ *DeleteGrantCategoryResponse, err_ error) {
-	req_ := &graphql.Request{
-		OpName: "DeleteGrantCategory",
-		Query:  DeleteGrantCategory_Operation,
-		Variables: &__DeleteGrantCategoryInput{
-			Id: id,
-		},
-	}
-
-	data_ = &DeleteGrantCategoryResponse{}
-	resp_ := &graphql.Response{Data: data_}
-
-	err_ = client_.MakeRequest(
-		ctx_,
-		req_,
-		resp_,
-	)
-
-	return data_, err_
-}
-
-// The mutation executed by DeleteIdentityStore.
-const DeleteIdentityStore_Operation = `
-mutation DeleteIdentityStore ($id: ID!) {
-	deleteIdentityStore(id: $id) {
-		__typename
-		... PermissionDeniedError
-		... on DeleteIdentityStore {
-			success
-		}
-	}
-}
-fragment PermissionDeniedError on PermissionDeniedError {
-	message
-}
-`
-
-func DeleteIdentityStore(
+func DeleteAccessProvider(
+	ctx_ context.Context,
+	client_ graphql.Client,
+	id string,
+	overrideLocks *bool,
+) (data_ *DeleteAccessProviderResponse, err_ error) {
+	req_ := &graphql.Request{
+		OpName: "DeleteAccessProvider",
+		Query:  DeleteAccessProvider_Operation,
+		Variables: &__DeleteAccessProviderInput{
+			Id:            id,
+			OverrideLocks: overrideLocks,
+		},
+	}
+
+	data_ = &DeleteAccessProviderResponse{}
+	resp_ := &graphql.Response{Data: data_}
+
+	err_ = client_.MakeRequest(
+		ctx_,
+		req_,
+		resp_,
+	)
+
+	return data_, err_
+}
+
+// The mutation executed by DeleteDataSource.
+const DeleteDataSource_Operation = `
+mutation DeleteDataSource ($id: ID!) {
+	deleteDataSource(id: $id) {
+		__typename
+		... PermissionDeniedError
+		... on DeleteDataSource {
+			success
+		}
+	}
+}
+fragment PermissionDeniedError on PermissionDeniedError {
+	message
+}
+`
+
+func DeleteDataSource(
+	ctx_ context.Context,
+	client_ graphql.Client,
+	id string,
+) (data_ *DeleteDataSourceResponse, err_ error) {
+	req_ := &graphql.Request{
+		OpName: "DeleteDataSource",
+		Query:  DeleteDataSource_Operation,
+		Variables: &__DeleteDataSourceInput{
+			Id: id,
+		},
+	}
+
+	data_ = &DeleteDataSourceResponse{}
+	resp_ := &graphql.Response{Data: data_}
+
+	err_ = client_.MakeRequest(
+		ctx_,
+		req_,
+		resp_,
+	)
+
+	return data_, err_
+}
+
+// The mutation executed by DeleteGrantCategory.
+const DeleteGrantCategory_Operation = `
+mutation DeleteGrantCategory ($id: ID!) {
+	deleteGrantCategory(id: $id) {
+		__typename
+		... DeleteGrantCategoryResult
+		... PermissionDeniedError
+		... NotFoundError
+		... InvalidInputError
+	}
+}
+fragment DeleteGrantCategoryResult on DeleteGrantCategory {
+	success
+}
+fragment PermissionDeniedError on PermissionDeniedError {
+	message
+}
+fragment NotFoundError on NotFoundError {
+	message
+}
+fragment InvalidInputError on InvalidInputError {
+	message
+}
+`
+
+func DeleteGrantCategory(
+	ctx_ context.Context,
+	client_ graphql.Client,
+	id string,
+) (data_ *DeleteGrantCategoryResponse, err_ error) {
+	req_ := &graphql.Request{
+		OpName: "DeleteGrantCategory",
+		Query:  DeleteGrantCategory_Operation,
+		Variables: &__DeleteGrantCategoryInput{
+			Id: id,
+		},
+	}
+
+	data_ = &DeleteGrantCategoryResponse{}
+	resp_ := &graphql.Response{Data: data_}
+
+	err_ = client_.MakeRequest(
+		ctx_,
+		req_,
+		resp_,
+	)
+
+	return data_, err_
+}
+
+// The mutation executed by DeleteIdentityStore.
+const DeleteIdentityStore_Operation = `
+mutation DeleteIdentityStore ($id: ID!) {
+	deleteIdentityStore(id: $id) {
+		__typename
+		... PermissionDeniedError
+		... on DeleteIdentityStore {
+			success
+		}
+	}
+}
+fragment PermissionDeniedError on PermissionDeniedError {
+	message
+}
+`
+
+func DeleteIdentityStore(
+	ctx_ context.Context,
+	client_ graphql.Client,
+	id string,
+) (data_ *DeleteIdentityStoreResponse, err_ error) {
+	req_ := &graphql.Request{
+		OpName: "DeleteIdentityStore",
+		Query:  DeleteIdentityStore_Operation,
+		Variables: &__DeleteIdentityStoreInput{
+			Id: id,
+		},
+	}
+
+	data_ = &DeleteIdentityStoreResponse{}
+	resp_ := &graphql.Response{Data: data_}
+
+	err_ = client_.MakeRequest(
+		ctx_,
+		req_,
+		resp_,
+	)
+
+	return data_, err_
+}
+
+// The mutation executed by DeleteUser.
+const DeleteUser_Operation = `
+mutation DeleteUser ($uId: ID!) {
+	deleteUser(id: $uId) {
+		__typename
+		... on UserDelete {
+			success
+		}
+		... PermissionDeniedError
+	}
+}
+fragment PermissionDeniedError on PermissionDeniedError {
+	message
+}
+`
+
+func DeleteUser(
+	ctx_ context.Context,
+	client_ graphql.Client,
+	uId string,
+) (data_ *DeleteUserResponse, err_ error) {
+	req_ := &graphql.Request{
+		OpName: "DeleteUser",
+		Query:  DeleteUser_Operation,
+		Variables: &__DeleteUserInput{
+			UId: uId,
+		},
+	}
+
+	data_ = &DeleteUserResponse{}
+	resp_ := &graphql.Response{Data: data_}
+
+	err_ = client_.MakeRequest(
+		ctx_,
+		req_,
+		resp_,
+	)
+
+	return data_, err_
+}
+
+// The query executed by GetAccessProvider.
+const GetAccessProvider_Operation = `
+query GetAccessProvider ($id: ID!) {
+	accessProvider(id: $id) {
+		__typename
+		... AccessProvider
+		... PermissionDeniedError
+		... NotFoundError
+		... InvalidInputError
+	}
+}
+fragment AccessProvider on AccessProvider {
+	id
+	isSample
+	createdAt
+	modifiedAt
+	name
+	namingHint
+	state
+	action
+	category {
+		... GrantCategory
+	}
+	description
+	policyRule
+	external
+	whatType
+	whatAbacRule {
+		... WhatAbacRule
+	}
+	whoType
+	whoAbacRule {
+		... WhoAbacRule
+	}
+	notInternalizable
+	complete
+	locks {
+		... AccessProviderLocks
+	}
+	syncData {
+		... SyncData
+	}
+}
+fragment PermissionDeniedError on PermissionDeniedError {
+	message
+}
+fragment NotFoundError on NotFoundError {
+	message
+}
+fragment InvalidInputError on InvalidInputError {
+	message
+}
+fragment GrantCategory on GrantCategory {
+	id
+	name
+	isSystem
+	isDefault
+}
+fragment WhatAbacRule on WhatAbacRule {
+	permissions
+	globalPermissions
+	doTypes
+	ruleJson
+}
+fragment WhoAbacRule on WhoAbacRule {
+	promiseDuration
+	type
+	ruleJson
+}
+fragment AccessProviderLocks on AccessProviderLockData {
+	lockKey
+	details {
+		... AccessProviderLockDetails
+	}
+}
+fragment SyncData on SyncData {
+	dataSource {
+		... DataSource
+	}
+	accessProviderType {
+		type
+	}
+	actualName
+	maskType {
+		... MaskType
+	}
+	syncStatus
+}
+fragment AccessProviderLockDetails on AccessProviderLockDetails {
+	reason
+}
+fragment DataSource on DataSource {
+	id
+	name
+	type
+	description
+	createdAt
+	modifiedAt
+	description
+	syncMethod
+	parent {
+		id
+	}
+}
+fragment MaskType on MaskType {
+	externalId
+	displayName
+	description
+	dataTypes
+}
+`
+
+func GetAccessProvider(
 	ctx_ context.Context,
 	client_ graphql.Client,
 	id string,
-) (data_ *DeleteIdentityStoreResponse, err_ error) {
+) (data_ *GetAccessProviderResponse, err_ error) {
 	req_ := &graphql.Request{
-		OpName: "DeleteIdentityStore",
-		Query:  DeleteIdentityStore_Operation,
-		Variables: &__DeleteIdentityStoreInput{
+		OpName: "GetAccessProvider",
+		Query:  GetAccessProvider_Operation,
+		Variables: &__GetAccessProviderInput{
 			Id: id,
 		},
 	}
 
-	data_ = &DeleteIdentityStoreResponse{}
-	resp_ := &graphql.Response{Data: data_}
-
-	err_ = client_.MakeRequest(
-		ctx_,
-		req_,
-		resp_,
-	)
-
-	return data_, err_
-}
-
-// The mutation executed by DeleteUser.
-const DeleteUser_Operation = `
-mutation DeleteUser ($uId: ID!) {
-	deleteUser(id: $uId) {
-		__typename
-		... on UserDelete {
-			success
-		}
-		... PermissionDeniedError
-	}
-}
-fragment PermissionDeniedError on PermissionDeniedError {
-	message
-}
-`
-
-func DeleteUser(
-	ctx_ context.Context,
-	client_ graphql.Client,
-	uId string,
-) (data_ *DeleteUserResponse, err_ error) {
-	req_ := &graphql.Request{
-		OpName: "DeleteUser",
-		Query:  DeleteUser_Operation,
-		Variables: &__DeleteUserInput{
-			UId: uId,
-		},
-	}
-
-	data_ = &DeleteUserResponse{}
+	data_ = &GetAccessProviderResponse{}
 	resp_ := &graphql.Response{Data: data_}
 
 	err_ = client_.MakeRequest(
@@ -50936,48 +52809,23 @@ func DeleteUser(
 	return data_, err_
 }
 
-// The query executed by GetAccessProvider.
-const GetAccessProvider_Operation = `
-query GetAccessProvider ($id: ID!) {
+// The query executed by GetAccessProviderTags.
+const GetAccessProviderTags_Operation = `
+query GetAccessProviderTags ($id: ID!) {
 	accessProvider(id: $id) {
 		__typename
-		... AccessProvider
+		... on AccessProvider {
+			tags {
+				... Tag
+			}
+		}
 		... PermissionDeniedError
 		... NotFoundError
-		... InvalidInputError
 	}
 }
-fragment AccessProvider on AccessProvider {
-	id
-	isSample
-	createdAt
-	modifiedAt
-	name
-	namingHint
-	state
-	action
-	category {
-		... GrantCategory
-	}
-	description
-	policyRule
-	external
-	whatType
-	whatAbacRule {
-		... WhatAbacRule
-	}
-	whoType
-	whoAbacRule {
-		... WhoAbacRule
-	}
-	notInternalizable
-	complete
-	locks {
-		... AccessProviderLocks
-	}
-	syncData {
-		... SyncData
-	}
+fragment Tag on Tag {
+	key
+	stringValue
 }
 fragment PermissionDeniedError on PermissionDeniedError {
 	message
@@ -50985,83 +52833,22 @@ fragment PermissionDeniedError on PermissionDeniedError {
 fragment NotFoundError on NotFoundError {
 	message
 }
-fragment InvalidInputError on InvalidInputError {
-	message
-}
-fragment GrantCategory on GrantCategory {
-	id
-	name
-	isSystem
-	isDefault
-}
-fragment WhatAbacRule on WhatAbacRule {
-	permissions
-	globalPermissions
-	doTypes
-	ruleJson
-}
-fragment WhoAbacRule on WhoAbacRule {
-	promiseDuration
-	type
-	ruleJson
-}
-fragment AccessProviderLocks on AccessProviderLockData {
-	lockKey
-	details {
-		... AccessProviderLockDetails
-	}
-}
-fragment SyncData on SyncData {
-	dataSource {
-		... DataSource
-	}
-	accessProviderType {
-		type
-	}
-	actualName
-	maskType {
-		... MaskType
-	}
-	syncStatus
-}
-fragment AccessProviderLockDetails on AccessProviderLockDetails {
-	reason
-}
-fragment DataSource on DataSource {
-	id
-	name
-	type
-	description
-	createdAt
-	modifiedAt
-	description
-	syncMethod
-	parent {
-		id
-	}
-}
-fragment MaskType on MaskType {
-	externalId
-	displayName
-	description
-	dataTypes
-}
 `
 
-func GetAccessProvider(
+func GetAccessProviderTags(
 	ctx_ context.Context,
 	client_ graphql.Client,
 	id string,
-) (data_ *GetAccessProviderResponse, err_ error) {
+) (data_ *GetAccessProviderTagsResponse, err_ error) {
 	req_ := &graphql.Request{
-		OpName: "GetAccessProvider",
-		Query:  GetAccessProvider_Operation,
-		Variables: &__GetAccessProviderInput{
+		OpName: "GetAccessProviderTags",
+		Query:  GetAccessProviderTags_Operation,
+		Variables: &__GetAccessProviderTagsInput{
 			Id: id,
 		},
 	}
 
-	data_ = &GetAccessProviderResponse{}
+	data_ = &GetAccessProviderTagsResponse{}
 	resp_ := &graphql.Response{Data: data_}
 
 	err_ = client_.MakeRequest(
@@ -51396,6 +53183,10 @@ fragment AccessProviderWhoListItem on AccessWhoItem {
 		... on User {
 			... User
 		}
+		... on DataSource {
+			id
+			name
+		}
 	}
 }
 fragment User on User {
@@ -51486,6 +53277,46 @@ func GetDataObject(
 	return data_, err_
 }
 
+// The query executed by GetDataObjectTags.
+const GetDataObjectTags_Operation = `
+query GetDataObjectTags ($dataObjectId: ID!) {
+	dataObject(id: $dataObjectId) {
+		tags {
+			... Tag
+		}
+	}
+}
+fragment Tag on Tag {
+	key
+	stringValue
+}
+`
+
+func GetDataObjectTags(
+	ctx_ context.Context,
+	client_ graphql.Client,
+	dataObjectId string,
+) (data_ *GetDataObjectTagsResponse, err_ error) {
+	req_ := &graphql.Request{
+		OpName: "GetDataObjectTags",
+		Query:  GetDataObjectTags_Operation,
+		Variables: &__GetDataObjectTagsInput{
+			DataObjectId: dataObjectId,
+		},
+	}
+
+	data_ = &GetDataObjectTagsResponse{}
+	resp_ := &graphql.Response{Data: data_}
+
+	err_ = client_.MakeRequest(
+		ctx_,
+		req_,
+		resp_,
+	)
+
+	return data_, err_
+}
+
 // The query executed by GetDataSource.
 const GetDataSource_Operation = `
 query GetDataSource ($id: ID!) {
@@ -51542,6 +53373,83 @@ func GetDataSource(
 	return data_, err_
 }
 
+// The query executed by GetDataSourceCapabilities.
+const GetDataSourceCapabilities_Operation = `
+query GetDataSourceCapabilities ($id: ID!) {
+	dataSource(id: $id) {
+		__typename
+		... DataSource
+		... on DataSource {
+			supportedFeatures
+			accessProviderTypes {
+				type
+			}
+			maskingMetadata {
+				... MaskingMetadata
+			}
+		}
+		... PermissionDeniedError
+		... NotFoundError
+	}
+}
+fragment DataSource on DataSource {
+	id
+	name
+	type
+	description
+	createdAt
+	modifiedAt
+	description
+	syncMethod
+	parent {
+		id
+	}
+}
+fragment MaskingMetadata on MaskingMetadata {
+	defaultMaskExternalName
+	maskTypes {
+		... MaskType
+	}
+}
+fragment PermissionDeniedError on PermissionDeniedError {
+	message
+}
+fragment NotFoundError on NotFoundError {
+	message
+}
+fragment MaskType on MaskType {
+	externalId
+	displayName
+	description
+	dataTypes
+}
+`
+
+func GetDataSourceCapabilities(
+	ctx_ context.Context,
+	client_ graphql.Client,
+	id string,
+) (data_ *GetDataSourceCapabilitiesResponse, err_ error) {
+	req_ := &graphql.Request{
+		OpName: "GetDataSourceCapabilities",
+		Query:  GetDataSourceCapabilities_Operation,
+		Variables: &__GetDataSourceCapabilitiesInput{
+			Id: id,
+		},
+	}
+
+	data_ = &GetDataSourceCapabilitiesResponse{}
+	resp_ := &graphql.Response{Data: data_}
+
+	err_ = client_.MakeRequest(
+		ctx_,
+		req_,
+		resp_,
+	)
+
+	return data_, err_
+}
+
 // The query executed by GetGrantCategory.
 const GetGrantCategory_Operation = `
 query GetGrantCategory ($id: ID!) {
@@ -51574,6 +53482,13 @@ fragment GrantCategoryDetails on GrantCategory {
 	allowedWhatItems {
 		... GrantCategoryAllowedWhatItems
 	}
+	canRequestAccess
+	descriptionMandatory
+	nameRegEx
+	nameRegExMsg
+	namingHintRegEx
+	namingHintRegExMsg
+	locksOnCreate
 }
 fragment PermissionDeniedError on PermissionDeniedError {
 	message
@@ -52342,6 +54257,13 @@ fragment GrantCategoryDetails on GrantCategory {
 	allowedWhatItems {
 		... GrantCategoryAllowedWhatItems
 	}
+	canRequestAccess
+	descriptionMandatory
+	nameRegEx
+	nameRegExMsg
+	namingHintRegEx
+	namingHintRegExMsg
+	locksOnCreate
 }
 fragment GrantCategoryTypeForDataSource on GrantCategoryTypeForDataSource {
 	DataSource
@@ -53195,6 +55117,64 @@ func ListRoles(
 	return data_, err_
 }
 
+// The mutation executed by MoveDataSource.
+const MoveDataSource_Operation = `
+mutation MoveDataSource ($id: ID!, $parent: ID) {
+	updateDataSource(id: $id, input: {parent:$parent}) {
+		__typename
+		... PermissionDeniedError
+		... NotFoundError
+		... DataSource
+	}
+}
+fragment PermissionDeniedError on PermissionDeniedError {
+	message
+}
+fragment NotFoundError on NotFoundError {
+	message
+}
+fragment DataSource on DataSource {
+	id
+	name
+	type
+	description
+	createdAt
+	modifiedAt
+	description
+	syncMethod
+	parent {
+		id
+	}
+}
+`
+
+func MoveDataSource(
+	ctx_ context.Context,
+	client_ graphql.Client,
+	id string,
+	parent *string,
+) (data_ *MoveDataSourceResponse, err_ error) {
+	req_ := &graphql.Request{
+		OpName: "MoveDataSource",
+		Query:  MoveDataSource_Operation,
+		Variables: &__MoveDataSourceInput{
+			Id:     id,
+			Parent: parent,
+		},
+	}
+
+	data_ = &MoveDataSourceResponse{}
+	resp_ := &graphql.Response{Data: data_}
+
+	err_ = client_.MakeRequest(
+		ctx_,
+		req_,
+		resp_,
+	)
+
+	return data_, err_
+}
+
 // The mutation executed by RemoveAsRaitoUser.
 const RemoveAsRaitoUser_Operation = `
 mutation RemoveAsRaitoUser ($uId: ID!) {
@@ -53902,6 +55882,13 @@ fragment GrantCategoryDetails on GrantCategory {
 	allowedWhatItems {
 		... GrantCategoryAllowedWhatItems
 	}
+	canRequestAccess
+	descriptionMandatory
+	nameRegEx
+	nameRegExMsg
+	namingHintRegEx
+	namingHintRegExMsg
+	locksOnCreate
 }
 fragment PermissionDeniedError on PermissionDeniedError {
 	message
@@ -0,0 +1,39 @@
+package scim
+
+import (
+	"context"
+	"errors"
+)
+
+var (
+	// ErrNotFound is returned by a Backend if the requested resource does not exist.
+	ErrNotFound = errors.New("resource not found")
+	// ErrConflict is returned by a Backend if a resource with the same unique attribute already exists.
+	ErrConflict = errors.New("resource already exists")
+	// ErrInvalidValue is returned if a resource or request contains an invalid value.
+	ErrInvalidValue = errors.New("invalid value")
+	// ErrInvalidFilter is returned if a filter cannot be parsed.
+	ErrInvalidFilter = errors.New("invalid filter")
+	// ErrInvalidPath is returned if a PATCH path cannot be parsed or does not match any value.
+	ErrInvalidPath = errors.New("invalid path")
+	// ErrNotSupported is returned by a Backend if it does not support an operation.
+	ErrNotSupported = errors.New("operation not supported")
+)
+
+// Backend stores the Users and Groups served by the Handler.
+// The filter passed to the list methods is nil if the request has no filter.
+// A Backend may use the filter to narrow the results, the Handler applies it to the returned resources as well.
+// Errors should wrap ErrNotFound, ErrConflict, ErrInvalidValue or ErrNotSupported to be returned with the matching SCIM status.
+type Backend interface {
+	GetUser(ctx context.Context, id string) (*User, error)
+	ListUsers(ctx context.Context, filter *Filter) ([]User, error)
+	CreateUser(ctx context.Context, user *User) (*User, error)
+	ReplaceUser(ctx context.Context, user *User) (*User, error)
+	DeleteUser(ctx context.Context, id string) error
+
+	GetGroup(ctx context.Context, id string) (*Group, error)
+	ListGroups(ctx context.Context, filter *Filter) ([]Group, error)
+	CreateGroup(ctx context.Context, group *Group) (*Group, error)
+	ReplaceGroup(ctx context.Context, group *Group) (*Group, error)
+	DeleteGroup(ctx context.Context, id string) error
+}
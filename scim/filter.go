@@ -0,0 +1,544 @@
+package scim
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Filter is a parsed SCIM 2.0 filter expression (RFC 7644, section 3.4.2.2).
+// Supported are the comparison operators eq, ne, co, sw, ew, gt, ge, lt, le and pr,
+// the logical operators and, or and not, grouping with parentheses and value paths like emails[type eq "work"].
+// String comparisons are case-insensitive.
+type Filter struct {
+	raw  string
+	expr filterExpr
+}
+
+// ParseFilter parses a SCIM filter expression.
+// Returns an error wrapping ErrInvalidFilter if the expression is not valid.
+func ParseFilter(filter string) (*Filter, error) {
+	tokens, err := tokenizeFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	p := filterParser{tokens: tokens}
+
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("%w: unexpected %q", ErrInvalidFilter, p.tokens[p.pos].text)
+	}
+
+	return &Filter{raw: filter, expr: expr}, nil
+}
+
+// String returns the filter expression as it was parsed.
+func (f *Filter) String() string {
+	if f == nil {
+		return ""
+	}
+
+	return f.raw
+}
+
+// Match returns true if the resource matches the filter.
+// The resource is matched on its JSON representation. A nil filter matches all resources.
+func (f *Filter) Match(resource any) bool {
+	if f == nil {
+		return true
+	}
+
+	value, err := toJSONValue(resource)
+	if err != nil {
+		return false
+	}
+
+	return f.expr.match(value)
+}
+
+// Equality returns the attribute and value if the filter is a single equality comparison with a string, like userName eq "john".
+// Backends can use it to look up a resource directly instead of listing all resources.
+func (f *Filter) Equality() (attribute string, value string, ok bool) {
+	if f == nil {
+		return "", "", false
+	}
+
+	comparison, isComparison := f.expr.(*comparisonExpr)
+	if !isComparison || comparison.op != "eq" {
+		return "", "", false
+	}
+
+	value, ok = comparison.value.(string)
+	if !ok {
+		return "", "", false
+	}
+
+	return strings.Join(comparison.path, "."), value, true
+}
+
+type filterExpr interface {
+	match(value any) bool
+}
+
+type logicalExpr struct {
+	and         bool
+	left, right filterExpr
+}
+
+func (e *logicalExpr) match(value any) bool {
+	if e.and {
+		return e.left.match(value) && e.right.match(value)
+	}
+
+	return e.left.match(value) || e.right.match(value)
+}
+
+type notExpr struct {
+	expr filterExpr
+}
+
+func (e *notExpr) match(value any) bool {
+	return !e.expr.match(value)
+}
+
+type comparisonExpr struct {
+	path  []string
+	op    string
+	value any
+}
+
+func (e *comparisonExpr) match(value any) bool {
+	values := resolveAttribute(value, e.path)
+
+	switch e.op {
+	case "pr":
+		for _, v := range values {
+			if v != nil && v != "" {
+				return true
+			}
+		}
+
+		return false
+	case "ne":
+		for _, v := range values {
+			if compareValues("eq", v, e.value) {
+				return false
+			}
+		}
+
+		return true
+	default:
+		for _, v := range values {
+			if compareValues(e.op, v, e.value) {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+// valuePathExpr matches if any value of a multi-valued attribute matches the inner filter, like emails[type eq "work"].
+type valuePathExpr struct {
+	path []string
+	expr filterExpr
+}
+
+func (e *valuePathExpr) match(value any) bool {
+	for _, v := range resolveValues(value, e.path) {
+		if e.expr.match(v) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// resolveValues returns the values of the attribute path, flattening multi-valued attributes.
+func resolveValues(value any, path []string) []any {
+	values := []any{value}
+
+	for _, name := range path {
+		var next []any
+
+		for _, v := range values {
+			for _, item := range flatten(v) {
+				object, ok := item.(map[string]any)
+				if !ok {
+					continue
+				}
+
+				if key, found := findKey(object, name); found {
+					next = append(next, object[key])
+				}
+			}
+		}
+
+		values = next
+	}
+
+	var result []any
+	for _, v := range values {
+		result = append(result, flatten(v)...)
+	}
+
+	return result
+}
+
+// resolveAttribute returns the comparable values of the attribute path.
+// Complex values are compared on their value sub-attribute, as defined for multi-valued attributes.
+func resolveAttribute(value any, path []string) []any {
+	values := resolveValues(value, path)
+
+	for i, v := range values {
+		if object, ok := v.(map[string]any); ok {
+			if key, found := findKey(object, "value"); found {
+				values[i] = object[key]
+			} else {
+				values[i] = nil
+			}
+		}
+	}
+
+	return values
+}
+
+func flatten(value any) []any {
+	if list, ok := value.([]any); ok {
+		return list
+	}
+
+	return []any{value}
+}
+
+func compareValues(op string, actual any, expected any) bool {
+	switch expectedValue := expected.(type) {
+	case string:
+		actualValue, ok := actual.(string)
+		if !ok {
+			return false
+		}
+
+		actualValue = strings.ToLower(actualValue)
+		expectedValue = strings.ToLower(expectedValue)
+
+		switch op {
+		case "eq":
+			return actualValue == expectedValue
+		case "co":
+			return strings.Contains(actualValue, expectedValue)
+		case "sw":
+			return strings.HasPrefix(actualValue, expectedValue)
+		case "ew":
+			return strings.HasSuffix(actualValue, expectedValue)
+		case "gt":
+			return actualValue > expectedValue
+		case "ge":
+			return actualValue >= expectedValue
+		case "lt":
+			return actualValue < expectedValue
+		case "le":
+			return actualValue <= expectedValue
+		}
+	case float64:
+		actualValue, ok := actual.(float64)
+		if !ok {
+			return false
+		}
+
+		switch op {
+		case "eq":
+			return actualValue == expectedValue
+		case "gt":
+			return actualValue > expectedValue
+		case "ge":
+			return actualValue >= expectedValue
+		case "lt":
+			return actualValue < expectedValue
+		case "le":
+			return actualValue <= expectedValue
+		}
+	case bool:
+		actualValue, ok := actual.(bool)
+
+		return ok && op == "eq" && actualValue == expectedValue
+	case nil:
+		return op == "eq" && actual == nil
+	}
+
+	return false
+}
+
+// findKey returns the key of the object that matches name case-insensitively.
+func findKey(object map[string]any, name string) (string, bool) {
+	if _, found := object[name]; found {
+		return name, true
+	}
+
+	for key := range object {
+		if strings.EqualFold(key, name) {
+			return key, true
+		}
+	}
+
+	return name, false
+}
+
+// toJSONValue converts a resource into its generic JSON representation.
+func toJSONValue(resource any) (any, error) {
+	switch resource.(type) {
+	case map[string]any, []any:
+		return resource, nil
+	}
+
+	data, err := json.Marshal(resource)
+	if err != nil {
+		return nil, err
+	}
+
+	var value any
+
+	err = json.Unmarshal(data, &value)
+	if err != nil {
+		return nil, err
+	}
+
+	return value, nil
+}
+
+// attributePath splits an attribute path into its attribute names, removing the core schema URN prefix.
+func attributePath(path string) []string {
+	for _, schema := range []string{UserSchema, GroupSchema} {
+		if len(path) > len(schema) && strings.EqualFold(path[:len(schema)+1], schema+":") {
+			path = path[len(schema)+1:]
+
+			break
+		}
+	}
+
+	return strings.Split(path, ".")
+}
+
+type filterToken struct {
+	text   string
+	quoted bool
+}
+
+func tokenizeFilter(filter string) ([]filterToken, error) {
+	var tokens []filterToken
+
+	for i := 0; i < len(filter); {
+		c := filter[i]
+
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '(' || c == ')' || c == '[' || c == ']':
+			tokens = append(tokens, filterToken{text: string(c)})
+			i++
+		case c == '"':
+			end := i + 1
+			for end < len(filter) && filter[end] != '"' {
+				if filter[end] == '\\' {
+					end++
+				}
+
+				end++
+			}
+
+			if end >= len(filter) {
+				return nil, fmt.Errorf("%w: unterminated string", ErrInvalidFilter)
+			}
+
+			var value string
+
+			err := json.Unmarshal([]byte(filter[i:end+1]), &value)
+			if err != nil {
+				return nil, fmt.Errorf("%w: invalid string %s", ErrInvalidFilter, filter[i:end+1])
+			}
+
+			tokens = append(tokens, filterToken{text: value, quoted: true})
+			i = end + 1
+		default:
+			end := i
+			for end < len(filter) && !strings.ContainsRune(" \t\n\r()[]\"", rune(filter[end])) {
+				end++
+			}
+
+			tokens = append(tokens, filterToken{text: filter[i:end]})
+			i = end
+		}
+	}
+
+	return tokens, nil
+}
+
+type filterParser struct {
+	tokens []filterToken
+	pos    int
+}
+
+func (p *filterParser) peekKeyword(keyword string) bool {
+	return p.pos < len(p.tokens) && !p.tokens[p.pos].quoted && strings.EqualFold(p.tokens[p.pos].text, keyword)
+}
+
+func (p *filterParser) expect(text string) error {
+	if !p.peekKeyword(text) {
+		return fmt.Errorf("%w: expected %q", ErrInvalidFilter, text)
+	}
+
+	p.pos++
+
+	return nil
+}
+
+func (p *filterParser) parseOr() (filterExpr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peekKeyword("or") {
+		p.pos++
+
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+
+		left = &logicalExpr{left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (p *filterParser) parseAnd() (filterExpr, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peekKeyword("and") {
+		p.pos++
+
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+
+		left = &logicalExpr{and: true, left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (p *filterParser) parseUnary() (filterExpr, error) {
+	if p.peekKeyword("not") {
+		p.pos++
+
+		err := p.expect("(")
+		if err != nil {
+			return nil, err
+		}
+
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+
+		err = p.expect(")")
+		if err != nil {
+			return nil, err
+		}
+
+		return &notExpr{expr: expr}, nil
+	}
+
+	if p.peekKeyword("(") {
+		p.pos++
+
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+
+		err = p.expect(")")
+		if err != nil {
+			return nil, err
+		}
+
+		return expr, nil
+	}
+
+	return p.parseAttribute()
+}
+
+func (p *filterParser) parseAttribute() (filterExpr, error) {
+	if p.pos >= len(p.tokens) || p.tokens[p.pos].quoted {
+		return nil, fmt.Errorf("%w: expected attribute path", ErrInvalidFilter)
+	}
+
+	path := attributePath(p.tokens[p.pos].text)
+	p.pos++
+
+	if p.peekKeyword("[") {
+		p.pos++
+
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+
+		err = p.expect("]")
+		if err != nil {
+			return nil, err
+		}
+
+		return &valuePathExpr{path: path, expr: expr}, nil
+	}
+
+	if p.pos >= len(p.tokens) || p.tokens[p.pos].quoted {
+		return nil, fmt.Errorf("%w: expected operator after %q", ErrInvalidFilter, strings.Join(path, "."))
+	}
+
+	op := strings.ToLower(p.tokens[p.pos].text)
+	p.pos++
+
+	switch op {
+	case "pr":
+		return &comparisonExpr{path: path, op: op}, nil
+	case "eq", "ne", "co", "sw", "ew", "gt", "ge", "lt", "le":
+	default:
+		return nil, fmt.Errorf("%w: unknown operator %q", ErrInvalidFilter, op)
+	}
+
+	if p.pos >= len(p.tokens) {
+		return nil, fmt.Errorf("%w: expected value after %q", ErrInvalidFilter, op)
+	}
+
+	token := p.tokens[p.pos]
+	p.pos++
+
+	if token.quoted {
+		return &comparisonExpr{path: path, op: op, value: token.text}, nil
+	}
+
+	var value any
+
+	err := json.Unmarshal([]byte(token.text), &value)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid value %q", ErrInvalidFilter, token.text)
+	}
+
+	switch value.(type) {
+	case bool, float64, nil:
+		return &comparisonExpr{path: path, op: op, value: value}, nil
+	default:
+		return nil, fmt.Errorf("%w: invalid value %q", ErrInvalidFilter, token.text)
+	}
+}
@@ -0,0 +1,189 @@
+package scim
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilter_Match(t *testing.T) {
+	user := &User{
+		Id:          "1",
+		UserName:    "Alice@Example.com",
+		DisplayName: "Alice Smith",
+		Name:        &Name{GivenName: "Alice", FamilyName: "Smith"},
+		Active:      boolPtr(true),
+		Emails: []MultiValue{
+			{Value: "alice@example.com", Type: "work", Primary: true},
+			{Value: "alice@home.example", Type: "home"},
+		},
+	}
+
+	tests := []struct {
+		filter string
+		match  bool
+	}{
+		{filter: `userName eq "alice@example.com"`, match: true},
+		{filter: `USERNAME Eq "ALICE@EXAMPLE.COM"`, match: true},
+		{filter: `urn:ietf:params:scim:schemas:core:2.0:User:userName eq "alice@example.com"`, match: true},
+		{filter: `userName ne "alice@example.com"`, match: false},
+		{filter: `displayName co "smith"`, match: true},
+		{filter: `displayName sw "Bob"`, match: false},
+		{filter: `displayName ew "Smith"`, match: true},
+		{filter: `name.givenName eq "Alice"`, match: true},
+		{filter: `active eq true`, match: true},
+		{filter: `active eq false`, match: false},
+		{filter: `externalId pr`, match: false},
+		{filter: `emails pr`, match: true},
+		{filter: `emails eq "alice@home.example"`, match: true},
+		{filter: `emails.value eq "bob@example.com"`, match: false},
+		{filter: `emails[type eq "work" and value ew "example.com"]`, match: true},
+		{filter: `emails[type eq "home" and primary eq true]`, match: false},
+		{filter: `userName eq "bob" or displayName sw "alice"`, match: true},
+		{filter: `userName eq "bob" or (displayName sw "alice" and active eq false)`, match: false},
+		{filter: `not (userName eq "bob")`, match: true},
+		{filter: `displayName gt "Aa" and displayName lt "B"`, match: true},
+		{filter: `displayName eq "Alice \"Al\" Smith"`, match: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.filter, func(t *testing.T) {
+			filter, err := ParseFilter(test.filter)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.match, filter.Match(user))
+		})
+	}
+}
+
+func TestParseFilter_Invalid(t *testing.T) {
+	for _, filter := range []string{
+		``,
+		`userName`,
+		`userName eq`,
+		`userName xx "a"`,
+		`userName eq bob`,
+		`userName eq "unterminated`,
+		`(userName eq "a"`,
+		`emails[type eq "work"`,
+		`userName eq "a" and`,
+		`userName eq "a" "b"`,
+	} {
+		t.Run(filter, func(t *testing.T) {
+			_, err := ParseFilter(filter)
+			assert.ErrorIs(t, err, ErrInvalidFilter)
+		})
+	}
+}
+
+func TestFilter_Equality(t *testing.T) {
+	filter, err := ParseFilter(`userName eq "alice@example.com"`)
+	require.NoError(t, err)
+
+	attribute, value, ok := filter.Equality()
+	assert.True(t, ok)
+	assert.Equal(t, "userName", attribute)
+	assert.Equal(t, "alice@example.com", value)
+
+	filter, err = ParseFilter(`userName eq "a" or userName eq "b"`)
+	require.NoError(t, err)
+
+	_, _, ok = filter.Equality()
+	assert.False(t, ok)
+
+	var nilFilter *Filter
+
+	_, _, ok = nilFilter.Equality()
+	assert.False(t, ok)
+	assert.True(t, nilFilter.Match(&User{}))
+}
+
+func TestApplyPatch(t *testing.T) {
+	group := &Group{
+		Id:          "g1",
+		DisplayName: "Engineering",
+		Members:     []Member{{Value: "u1"}, {Value: "u2"}},
+	}
+
+	tests := []struct {
+		name       string
+		operations []PatchOperation
+		expected   *Group
+		err        error
+	}{
+		{
+			name:       "replace attribute",
+			operations: []PatchOperation{{Op: "replace", Path: "displayName", Value: "Platform"}},
+			expected:   &Group{Id: "g1", DisplayName: "Platform", Members: []Member{{Value: "u1"}, {Value: "u2"}}},
+		},
+		{
+			name:       "replace without path",
+			operations: []PatchOperation{{Op: "replace", Value: map[string]any{"displayName": "Platform", "externalId": "ext"}}},
+			expected:   &Group{Id: "g1", DisplayName: "Platform", ExternalId: "ext", Members: []Member{{Value: "u1"}, {Value: "u2"}}},
+		},
+		{
+			name:       "add members skips existing values",
+			operations: []PatchOperation{{Op: "add", Path: "members", Value: []any{map[string]any{"value": "u2"}, map[string]any{"value": "u3"}}}},
+			expected:   &Group{Id: "g1", DisplayName: "Engineering", Members: []Member{{Value: "u1"}, {Value: "u2"}, {Value: "u3"}}},
+		},
+		{
+			name:       "remove member with filter",
+			operations: []PatchOperation{{Op: "remove", Path: `members[value eq "u1"]`}},
+			expected:   &Group{Id: "g1", DisplayName: "Engineering", Members: []Member{{Value: "u2"}}},
+		},
+		{
+			name:       "remove member with value",
+			operations: []PatchOperation{{Op: "Remove", Path: "members", Value: []any{map[string]any{"value": "u2"}}}},
+			expected:   &Group{Id: "g1", DisplayName: "Engineering", Members: []Member{{Value: "u1"}}},
+		},
+		{
+			name:       "remove all members",
+			operations: []PatchOperation{{Op: "remove", Path: "members"}},
+			expected:   &Group{Id: "g1", DisplayName: "Engineering"},
+		},
+		{
+			name:       "replace sub attribute with filter",
+			operations: []PatchOperation{{Op: "replace", Path: `members[value eq "u2"].display`, Value: "User 2"}},
+			expected:   &Group{Id: "g1", DisplayName: "Engineering", Members: []Member{{Value: "u1"}, {Value: "u2", Display: "User 2"}}},
+		},
+		{
+			name:       "replace with filter without match",
+			operations: []PatchOperation{{Op: "replace", Path: `members[value eq "u9"].display`, Value: "User 9"}},
+			err:        ErrInvalidPath,
+		},
+		{
+			name:       "remove without path",
+			operations: []PatchOperation{{Op: "remove"}},
+			err:        ErrInvalidPath,
+		},
+		{
+			name:       "unknown operation",
+			operations: []PatchOperation{{Op: "move", Path: "displayName"}},
+			err:        ErrInvalidValue,
+		},
+		{
+			name:       "invalid path filter",
+			operations: []PatchOperation{{Op: "remove", Path: `members[value xx "u1"]`}},
+			err:        ErrInvalidPath,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var result Group
+
+			err := ApplyPatch(group, test.operations, &result)
+			if test.err != nil {
+				assert.ErrorIs(t, err, test.err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, &result)
+		})
+	}
+
+	assert.Equal(t, []Member{{Value: "u1"}, {Value: "u2"}}, group.Members, "the patched resource should not be modified")
+}
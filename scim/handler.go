@@ -0,0 +1,556 @@
+package scim
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	defaultMaxResults  = 100
+	defaultMaxBodySize = 1 << 20
+)
+
+type HandlerOptions struct {
+	baseURL     string
+	maxResults  int
+	maxBodySize int64
+	logger      *slog.Logger
+}
+
+// WithBaseURL sets the URL the Handler is served on. It is used for the location of resources.
+func WithBaseURL(baseURL string) func(options *HandlerOptions) {
+	return func(options *HandlerOptions) {
+		options.baseURL = strings.TrimSuffix(baseURL, "/")
+	}
+}
+
+// WithMaxResults sets the maximum number of resources returned in a single list response. Defaults to 100.
+func WithMaxResults(maxResults int) func(options *HandlerOptions) {
+	return func(options *HandlerOptions) {
+		options.maxResults = maxResults
+	}
+}
+
+// WithMaxBodySize sets the maximum size in bytes of a request body. Larger requests are rejected. Defaults to 1 MiB.
+func WithMaxBodySize(maxBodySize int64) func(options *HandlerOptions) {
+	return func(options *HandlerOptions) {
+		options.maxBodySize = maxBodySize
+	}
+}
+
+// WithLogger sets the logger used to report internal errors. Defaults to slog.Default().
+// The cause of an internal error is only logged, the response contains a generic message.
+func WithLogger(logger *slog.Logger) func(options *HandlerOptions) {
+	return func(options *HandlerOptions) {
+		options.logger = logger
+	}
+}
+
+// Handler is an http.Handler implementing the SCIM 2.0 /Users and /Groups endpoints on top of a Backend.
+// The Handler expects to be served at the root of the SCIM base URL. Use http.StripPrefix to serve it on a sub path.
+// Every request must carry the bearer token of the Handler in its Authorization header.
+type Handler struct {
+	backend     Backend
+	bearerToken string
+	options     HandlerOptions
+	mux         *http.ServeMux
+}
+
+// NewHandler creates a new Handler serving the Users and Groups of the given Backend.
+// Requests are authenticated with the given bearer token. If the token is empty, all requests are rejected.
+func NewHandler(backend Backend, bearerToken string, ops ...func(options *HandlerOptions)) *Handler {
+	options := HandlerOptions{
+		maxResults:  defaultMaxResults,
+		maxBodySize: defaultMaxBodySize,
+		logger:      slog.Default(),
+	}
+
+	for _, op := range ops {
+		op(&options)
+	}
+
+	h := &Handler{
+		backend:     backend,
+		bearerToken: bearerToken,
+		options:     options,
+		mux:         http.NewServeMux(),
+	}
+
+	h.mux.HandleFunc("GET /Users", h.listUsers)
+	h.mux.HandleFunc("POST /Users", h.createUser)
+	h.mux.HandleFunc("GET /Users/{id}", h.getUser)
+	h.mux.HandleFunc("PUT /Users/{id}", h.replaceUser)
+	h.mux.HandleFunc("PATCH /Users/{id}", h.patchUser)
+	h.mux.HandleFunc("DELETE /Users/{id}", h.deleteUser)
+
+	h.mux.HandleFunc("GET /Groups", h.listGroups)
+	h.mux.HandleFunc("POST /Groups", h.createGroup)
+	h.mux.HandleFunc("GET /Groups/{id}", h.getGroup)
+	h.mux.HandleFunc("PUT /Groups/{id}", h.replaceGroup)
+	h.mux.HandleFunc("PATCH /Groups/{id}", h.patchGroup)
+	h.mux.HandleFunc("DELETE /Groups/{id}", h.deleteGroup)
+
+	h.mux.HandleFunc("GET /ServiceProviderConfig", h.serviceProviderConfig)
+
+	return h
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	token, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !found || h.bearerToken == "" || subtle.ConstantTimeCompare([]byte(token), []byte(h.bearerToken)) != 1 {
+		writeError(w, http.StatusUnauthorized, "", "invalid or missing bearer token")
+
+		return
+	}
+
+	h.mux.ServeHTTP(w, r)
+}
+
+func (h *Handler) listUsers(w http.ResponseWriter, r *http.Request) {
+	filter, startIndex, count, err := h.listParameters(r)
+	if err != nil {
+		h.writeBackendError(w, r, err)
+
+		return
+	}
+
+	users, err := h.backend.ListUsers(r.Context(), filter)
+	if err != nil {
+		h.writeBackendError(w, r, err)
+
+		return
+	}
+
+	matched := make([]User, 0, len(users))
+
+	for i := range users {
+		if filter.Match(&users[i]) {
+			h.userMeta(&users[i])
+			matched = append(matched, users[i])
+		}
+	}
+
+	writeJSON(w, http.StatusOK, listResponse(matched, startIndex, count))
+}
+
+func (h *Handler) getUser(w http.ResponseWriter, r *http.Request) {
+	user, err := h.backend.GetUser(r.Context(), r.PathValue("id"))
+	if err != nil {
+		h.writeBackendError(w, r, err)
+
+		return
+	}
+
+	h.writeUser(w, http.StatusOK, user)
+}
+
+func (h *Handler) createUser(w http.ResponseWriter, r *http.Request) {
+	var user User
+
+	err := h.decodeBody(w, r, &user)
+	if err != nil {
+		h.writeBackendError(w, r, err)
+
+		return
+	}
+
+	if user.UserName == "" {
+		writeError(w, http.StatusBadRequest, "invalidValue", "userName is required")
+
+		return
+	}
+
+	created, err := h.backend.CreateUser(r.Context(), &user)
+	if err != nil {
+		h.writeBackendError(w, r, err)
+
+		return
+	}
+
+	h.writeUser(w, http.StatusCreated, created)
+}
+
+func (h *Handler) replaceUser(w http.ResponseWriter, r *http.Request) {
+	var user User
+
+	err := h.decodeBody(w, r, &user)
+	if err != nil {
+		h.writeBackendError(w, r, err)
+
+		return
+	}
+
+	user.Id = r.PathValue("id")
+
+	replaced, err := h.backend.ReplaceUser(r.Context(), &user)
+	if err != nil {
+		h.writeBackendError(w, r, err)
+
+		return
+	}
+
+	h.writeUser(w, http.StatusOK, replaced)
+}
+
+func (h *Handler) patchUser(w http.ResponseWriter, r *http.Request) {
+	var patch PatchRequest
+
+	err := h.decodeBody(w, r, &patch)
+	if err != nil {
+		h.writeBackendError(w, r, err)
+
+		return
+	}
+
+	id := r.PathValue("id")
+
+	user, err := h.backend.GetUser(r.Context(), id)
+	if err != nil {
+		h.writeBackendError(w, r, err)
+
+		return
+	}
+
+	var patched User
+
+	err = ApplyPatch(user, patch.Operations, &patched)
+	if err != nil {
+		h.writeBackendError(w, r, err)
+
+		return
+	}
+
+	patched.Id = id
+
+	replaced, err := h.backend.ReplaceUser(r.Context(), &patched)
+	if err != nil {
+		h.writeBackendError(w, r, err)
+
+		return
+	}
+
+	h.writeUser(w, http.StatusOK, replaced)
+}
+
+func (h *Handler) deleteUser(w http.ResponseWriter, r *http.Request) {
+	err := h.backend.DeleteUser(r.Context(), r.PathValue("id"))
+	if err != nil {
+		h.writeBackendError(w, r, err)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) listGroups(w http.ResponseWriter, r *http.Request) {
+	filter, startIndex, count, err := h.listParameters(r)
+	if err != nil {
+		h.writeBackendError(w, r, err)
+
+		return
+	}
+
+	groups, err := h.backend.ListGroups(r.Context(), filter)
+	if err != nil {
+		h.writeBackendError(w, r, err)
+
+		return
+	}
+
+	matched := make([]Group, 0, len(groups))
+
+	for i := range groups {
+		if filter.Match(&groups[i]) {
+			h.groupMeta(&groups[i])
+			matched = append(matched, groups[i])
+		}
+	}
+
+	writeJSON(w, http.StatusOK, listResponse(matched, startIndex, count))
+}
+
+func (h *Handler) getGroup(w http.ResponseWriter, r *http.Request) {
+	group, err := h.backend.GetGroup(r.Context(), r.PathValue("id"))
+	if err != nil {
+		h.writeBackendError(w, r, err)
+
+		return
+	}
+
+	h.writeGroup(w, http.StatusOK, group)
+}
+
+func (h *Handler) createGroup(w http.ResponseWriter, r *http.Request) {
+	var group Group
+
+	err := h.decodeBody(w, r, &group)
+	if err != nil {
+		h.writeBackendError(w, r, err)
+
+		return
+	}
+
+	if group.DisplayName == "" {
+		writeError(w, http.StatusBadRequest, "invalidValue", "displayName is required")
+
+		return
+	}
+
+	created, err := h.backend.CreateGroup(r.Context(), &group)
+	if err != nil {
+		h.writeBackendError(w, r, err)
+
+		return
+	}
+
+	h.writeGroup(w, http.StatusCreated, created)
+}
+
+func (h *Handler) replaceGroup(w http.ResponseWriter, r *http.Request) {
+	var group Group
+
+	err := h.decodeBody(w, r, &group)
+	if err != nil {
+		h.writeBackendError(w, r, err)
+
+		return
+	}
+
+	group.Id = r.PathValue("id")
+
+	replaced, err := h.backend.ReplaceGroup(r.Context(), &group)
+	if err != nil {
+		h.writeBackendError(w, r, err)
+
+		return
+	}
+
+	h.writeGroup(w, http.StatusOK, replaced)
+}
+
+func (h *Handler) patchGroup(w http.ResponseWriter, r *http.Request) {
+	var patch PatchRequest
+
+	err := h.decodeBody(w, r, &patch)
+	if err != nil {
+		h.writeBackendError(w, r, err)
+
+		return
+	}
+
+	id := r.PathValue("id")
+
+	group, err := h.backend.GetGroup(r.Context(), id)
+	if err != nil {
+		h.writeBackendError(w, r, err)
+
+		return
+	}
+
+	var patched Group
+
+	err = ApplyPatch(group, patch.Operations, &patched)
+	if err != nil {
+		h.writeBackendError(w, r, err)
+
+		return
+	}
+
+	patched.Id = id
+
+	replaced, err := h.backend.ReplaceGroup(r.Context(), &patched)
+	if err != nil {
+		h.writeBackendError(w, r, err)
+
+		return
+	}
+
+	h.writeGroup(w, http.StatusOK, replaced)
+}
+
+func (h *Handler) deleteGroup(w http.ResponseWriter, r *http.Request) {
+	err := h.backend.DeleteGroup(r.Context(), r.PathValue("id"))
+	if err != nil {
+		h.writeBackendError(w, r, err)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) serviceProviderConfig(w http.ResponseWriter, _ *http.Request) {
+	supported := func(supported bool) map[string]any {
+		return map[string]any{"supported": supported}
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"schemas":        []string{"urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"},
+		"patch":          supported(true),
+		"bulk":           map[string]any{"supported": false, "maxOperations": 0, "maxPayloadSize": 0},
+		"filter":         map[string]any{"supported": true, "maxResults": h.options.maxResults},
+		"changePassword": supported(false),
+		"sort":           supported(false),
+		"etag":           supported(false),
+		"authenticationSchemes": []map[string]any{
+			{"type": "oauthbearertoken", "name": "OAuth Bearer Token", "description": "Authentication with a bearer token"},
+		},
+	})
+}
+
+// listParameters parses the filter, startIndex and count query parameters of a list request.
+func (h *Handler) listParameters(r *http.Request) (*Filter, int, int, error) {
+	query := r.URL.Query()
+
+	var filter *Filter
+
+	if query.Get("filter") != "" {
+		var err error
+
+		filter, err = ParseFilter(query.Get("filter"))
+		if err != nil {
+			return nil, 0, 0, err
+		}
+	}
+
+	startIndex := 1
+	count := h.options.maxResults
+
+	if value := query.Get("startIndex"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, 0, 0, fmt.Errorf("%w: startIndex %q", ErrInvalidValue, value)
+		}
+
+		startIndex = max(parsed, 1)
+	}
+
+	if value := query.Get("count"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, 0, 0, fmt.Errorf("%w: count %q", ErrInvalidValue, value)
+		}
+
+		count = min(max(parsed, 0), h.options.maxResults)
+	}
+
+	return filter, startIndex, count, nil
+}
+
+func listResponse[T any](resources []T, startIndex int, count int) *ListResponse[T] {
+	offset := min(startIndex-1, len(resources))
+	end := min(offset+count, len(resources))
+
+	return &ListResponse[T]{
+		Schemas:      []string{ListResponseSchema},
+		TotalResults: len(resources),
+		StartIndex:   startIndex,
+		ItemsPerPage: end - offset,
+		Resources:    resources[offset:end],
+	}
+}
+
+func (h *Handler) userMeta(user *User) {
+	user.Schemas = []string{UserSchema}
+
+	if user.Meta == nil {
+		user.Meta = &Meta{}
+	}
+
+	user.Meta.ResourceType = "User"
+	user.Meta.Location = h.options.baseURL + "/Users/" + user.Id
+}
+
+func (h *Handler) groupMeta(group *Group) {
+	group.Schemas = []string{GroupSchema}
+
+	if group.Meta == nil {
+		group.Meta = &Meta{}
+	}
+
+	group.Meta.ResourceType = "Group"
+	group.Meta.Location = h.options.baseURL + "/Groups/" + group.Id
+}
+
+func (h *Handler) writeUser(w http.ResponseWriter, status int, user *User) {
+	h.userMeta(user)
+
+	if status == http.StatusCreated {
+		w.Header().Set("Location", user.Meta.Location)
+	}
+
+	writeJSON(w, status, user)
+}
+
+func (h *Handler) writeGroup(w http.ResponseWriter, status int, group *Group) {
+	h.groupMeta(group)
+
+	if status == http.StatusCreated {
+		w.Header().Set("Location", group.Meta.Location)
+	}
+
+	writeJSON(w, status, group)
+}
+
+func (h *Handler) decodeBody(w http.ResponseWriter, r *http.Request, v any) error {
+	err := json.NewDecoder(http.MaxBytesReader(w, r.Body, h.options.maxBodySize)).Decode(v)
+
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		return err
+	} else if err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidValue, err.Error())
+	}
+
+	return nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", ContentType)
+	w.WriteHeader(status)
+
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, scimType string, detail string) {
+	writeJSON(w, status, &ErrorResponse{
+		Schemas:  []string{ErrorSchema},
+		Status:   strconv.Itoa(status),
+		ScimType: scimType,
+		Detail:   detail,
+	})
+}
+
+// writeBackendError writes the SCIM error matching err.
+// Unexpected errors are logged and returned with a generic detail, as they may contain internal information.
+func (h *Handler) writeBackendError(w http.ResponseWriter, r *http.Request, err error) {
+	var maxBytesErr *http.MaxBytesError
+
+	switch {
+	case errors.Is(err, ErrNotFound):
+		writeError(w, http.StatusNotFound, "", err.Error())
+	case errors.Is(err, ErrConflict):
+		writeError(w, http.StatusConflict, "uniqueness", err.Error())
+	case errors.Is(err, ErrInvalidFilter):
+		writeError(w, http.StatusBadRequest, "invalidFilter", err.Error())
+	case errors.Is(err, ErrInvalidPath):
+		writeError(w, http.StatusBadRequest, "invalidPath", err.Error())
+	case errors.Is(err, ErrInvalidValue):
+		writeError(w, http.StatusBadRequest, "invalidValue", err.Error())
+	case errors.Is(err, ErrNotSupported):
+		writeError(w, http.StatusNotImplemented, "", err.Error())
+	case errors.As(err, &maxBytesErr):
+		writeError(w, http.StatusRequestEntityTooLarge, "", fmt.Sprintf("request body exceeds %d bytes", maxBytesErr.Limit))
+	default:
+		h.options.logger.ErrorContext(r.Context(), "SCIM request failed", "method", r.Method, "path", r.URL.Path, "error", err)
+
+		writeError(w, http.StatusInternalServerError, "", "internal server error")
+	}
+}
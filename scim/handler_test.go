@@ -0,0 +1,328 @@
+package scim
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestServer(t *testing.T, ops ...func(options *HandlerOptions)) *httptest.Server {
+	t.Helper()
+
+	return newTestServerWithBackend(t, NewMemoryBackend(), "secret", ops...)
+}
+
+func newTestServerWithBackend(t *testing.T, backend Backend, bearerToken string, ops ...func(options *HandlerOptions)) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(NewHandler(backend, bearerToken, ops...))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func doRequest(t *testing.T, server *httptest.Server, method string, path string, body any, result any) int {
+	t.Helper()
+
+	var reader *bytes.Reader
+
+	if body != nil {
+		data, err := json.Marshal(body)
+		require.NoError(t, err)
+
+		reader = bytes.NewReader(data)
+	} else {
+		reader = bytes.NewReader(nil)
+	}
+
+	req, err := http.NewRequest(method, server.URL+path, reader)
+	require.NoError(t, err)
+
+	req.Header.Set("Content-Type", ContentType)
+	req.Header.Set("Authorization", "Bearer secret")
+
+	resp, err := server.Client().Do(req)
+	require.NoError(t, err)
+
+	defer resp.Body.Close()
+
+	if result != nil && resp.StatusCode != http.StatusNoContent {
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(result))
+	}
+
+	return resp.StatusCode
+}
+
+func createTestUser(t *testing.T, server *httptest.Server, userName string) User {
+	t.Helper()
+
+	var user User
+
+	status := doRequest(t, server, http.MethodPost, "/Users", map[string]any{
+		"schemas":  []string{UserSchema},
+		"userName": userName,
+		"name":     map[string]any{"givenName": "Test", "familyName": userName},
+		"emails":   []map[string]any{{"value": userName, "type": "work", "primary": true}},
+		"active":   true,
+	}, &user)
+	require.Equal(t, http.StatusCreated, status)
+
+	return user
+}
+
+func TestHandler_Users(t *testing.T) {
+	server := newTestServer(t)
+
+	created := createTestUser(t, server, "alice@example.com")
+	assert.NotEmpty(t, created.Id)
+	assert.Equal(t, []string{UserSchema}, created.Schemas)
+	assert.Equal(t, "User", created.Meta.ResourceType)
+	assert.Equal(t, "/Users/"+created.Id, created.Meta.Location)
+
+	var conflict ErrorResponse
+	status := doRequest(t, server, http.MethodPost, "/Users", map[string]any{"userName": "ALICE@example.com"}, &conflict)
+	assert.Equal(t, http.StatusConflict, status)
+	assert.Equal(t, "uniqueness", conflict.ScimType)
+
+	var fetched User
+	status = doRequest(t, server, http.MethodGet, "/Users/"+created.Id, nil, &fetched)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "alice@example.com", fetched.UserName)
+	assert.Equal(t, "alice@example.com", fetched.PrimaryEmail())
+
+	fetched.DisplayName = "Alice"
+	fetched.Active = boolPtr(false)
+
+	var replaced User
+	status = doRequest(t, server, http.MethodPut, "/Users/"+created.Id, fetched, &replaced)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "Alice", replaced.DisplayName)
+	assert.False(t, replaced.IsActive())
+
+	status = doRequest(t, server, http.MethodDelete, "/Users/"+created.Id, nil, nil)
+	assert.Equal(t, http.StatusNoContent, status)
+
+	var notFound ErrorResponse
+	status = doRequest(t, server, http.MethodGet, "/Users/"+created.Id, nil, &notFound)
+	assert.Equal(t, http.StatusNotFound, status)
+	assert.Equal(t, "404", notFound.Status)
+}
+
+func TestHandler_ListUsers(t *testing.T) {
+	server := newTestServer(t, WithMaxResults(2))
+
+	for i := range 5 {
+		createTestUser(t, server, "user"+strconv.Itoa(i)+"@example.com")
+	}
+
+	var page ListResponse[User]
+	status := doRequest(t, server, http.MethodGet, "/Users?startIndex=2&count=10", nil, &page)
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, 5, page.TotalResults)
+	assert.Equal(t, 2, page.StartIndex)
+	assert.Equal(t, 2, page.ItemsPerPage)
+	require.Len(t, page.Resources, 2)
+	assert.Equal(t, "user1@example.com", page.Resources[0].UserName)
+	assert.Equal(t, "user2@example.com", page.Resources[1].UserName)
+
+	var filtered ListResponse[User]
+	status = doRequest(t, server, http.MethodGet, "/Users?filter="+url.QueryEscape(`userName eq "USER3@example.com"`), nil, &filtered)
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, 1, filtered.TotalResults)
+	require.Len(t, filtered.Resources, 1)
+	assert.Equal(t, "user3@example.com", filtered.Resources[0].UserName)
+
+	var beyond ListResponse[User]
+	status = doRequest(t, server, http.MethodGet, "/Users?startIndex=10", nil, &beyond)
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, 5, beyond.TotalResults)
+	assert.Empty(t, beyond.Resources)
+
+	var invalid ErrorResponse
+	status = doRequest(t, server, http.MethodGet, "/Users?filter="+url.QueryEscape(`userName zz "x"`), nil, &invalid)
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, "invalidFilter", invalid.ScimType)
+}
+
+func TestHandler_PatchUser(t *testing.T) {
+	server := newTestServer(t)
+
+	created := createTestUser(t, server, "bob@example.com")
+
+	var patched User
+	status := doRequest(t, server, http.MethodPatch, "/Users/"+created.Id, PatchRequest{
+		Schemas: []string{PatchOpSchema},
+		Operations: []PatchOperation{
+			{Op: "Replace", Path: "active", Value: "False"},
+			{Op: "replace", Path: "name.givenName", Value: "Robert"},
+			{Op: "add", Path: "emails", Value: []any{map[string]any{"value": "robert@example.com", "type": "home"}}},
+			{Op: "replace", Value: map[string]any{"displayName": "Robert"}},
+		},
+	}, &patched)
+	require.Equal(t, http.StatusOK, status)
+	assert.False(t, patched.IsActive())
+	assert.Equal(t, "Robert", patched.Name.GivenName)
+	assert.Equal(t, "Robert", patched.DisplayName)
+	assert.Len(t, patched.Emails, 2)
+
+	status = doRequest(t, server, http.MethodPatch, "/Users/"+created.Id, PatchRequest{
+		Schemas:    []string{PatchOpSchema},
+		Operations: []PatchOperation{{Op: "remove", Path: `emails[type eq "home"]`}},
+	}, &patched)
+	require.Equal(t, http.StatusOK, status)
+	require.Len(t, patched.Emails, 1)
+	assert.Equal(t, "bob@example.com", patched.Emails[0].Value)
+
+	var invalid ErrorResponse
+	status = doRequest(t, server, http.MethodPatch, "/Users/"+created.Id, PatchRequest{
+		Schemas:    []string{PatchOpSchema},
+		Operations: []PatchOperation{{Op: "remove"}},
+	}, &invalid)
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, "invalidPath", invalid.ScimType)
+}
+
+func TestHandler_Groups(t *testing.T) {
+	server := newTestServer(t)
+
+	alice := createTestUser(t, server, "alice@example.com")
+	bob := createTestUser(t, server, "bob@example.com")
+
+	var group Group
+	status := doRequest(t, server, http.MethodPost, "/Groups", map[string]any{
+		"schemas":     []string{GroupSchema},
+		"displayName": "Engineering",
+		"members":     []map[string]any{{"value": alice.Id}},
+	}, &group)
+	require.Equal(t, http.StatusCreated, status)
+	assert.Len(t, group.Members, 1)
+
+	var invalid ErrorResponse
+	status = doRequest(t, server, http.MethodPost, "/Groups", map[string]any{
+		"displayName": "Unknown",
+		"members":     []map[string]any{{"value": "unknown"}},
+	}, &invalid)
+	assert.Equal(t, http.StatusBadRequest, status)
+
+	status = doRequest(t, server, http.MethodPatch, "/Groups/"+group.Id, PatchRequest{
+		Schemas: []string{PatchOpSchema},
+		Operations: []PatchOperation{
+			{Op: "add", Path: "members", Value: []any{map[string]any{"value": bob.Id}, map[string]any{"value": alice.Id}}},
+		},
+	}, &group)
+	require.Equal(t, http.StatusOK, status)
+	assert.Len(t, group.Members, 2)
+
+	var user User
+	status = doRequest(t, server, http.MethodGet, "/Users/"+bob.Id, nil, &user)
+	require.Equal(t, http.StatusOK, status)
+	require.Len(t, user.Groups, 1)
+	assert.Equal(t, group.Id, user.Groups[0].Value)
+
+	status = doRequest(t, server, http.MethodPatch, "/Groups/"+group.Id, PatchRequest{
+		Schemas: []string{PatchOpSchema},
+		Operations: []PatchOperation{
+			{Op: "remove", Path: "members", Value: []any{map[string]any{"value": alice.Id}}},
+		},
+	}, &group)
+	require.Equal(t, http.StatusOK, status)
+	require.Len(t, group.Members, 1)
+	assert.Equal(t, bob.Id, group.Members[0].Value)
+
+	var filtered ListResponse[Group]
+	status = doRequest(t, server, http.MethodGet, "/Groups?filter="+url.QueryEscape(`members[value eq "`+bob.Id+`"]`), nil, &filtered)
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, 1, filtered.TotalResults)
+
+	status = doRequest(t, server, http.MethodDelete, "/Users/"+bob.Id, nil, nil)
+	require.Equal(t, http.StatusNoContent, status)
+
+	var fetched Group
+	status = doRequest(t, server, http.MethodGet, "/Groups/"+group.Id, nil, &fetched)
+	require.Equal(t, http.StatusOK, status)
+	assert.Empty(t, fetched.Members)
+
+	status = doRequest(t, server, http.MethodDelete, "/Groups/"+group.Id, nil, nil)
+	assert.Equal(t, http.StatusNoContent, status)
+}
+
+func TestHandler_BearerToken(t *testing.T) {
+	tests := []struct {
+		name           string
+		bearerToken    string
+		authorization  string
+		expectedStatus int
+	}{
+		{name: "valid token", bearerToken: "secret", authorization: "Bearer secret", expectedStatus: http.StatusOK},
+		{name: "missing token", bearerToken: "secret", expectedStatus: http.StatusUnauthorized},
+		{name: "invalid token", bearerToken: "secret", authorization: "Bearer other", expectedStatus: http.StatusUnauthorized},
+		{name: "no token configured", authorization: "Bearer ", expectedStatus: http.StatusUnauthorized},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := newTestServerWithBackend(t, NewMemoryBackend(), test.bearerToken)
+
+			req, err := http.NewRequest(http.MethodGet, server.URL+"/Users", nil)
+			require.NoError(t, err)
+
+			if test.authorization != "" {
+				req.Header.Set("Authorization", test.authorization)
+			}
+
+			resp, err := server.Client().Do(req)
+			require.NoError(t, err)
+			resp.Body.Close()
+
+			assert.Equal(t, test.expectedStatus, resp.StatusCode)
+		})
+	}
+}
+
+func TestHandler_MaxBodySize(t *testing.T) {
+	server := newTestServer(t, WithMaxBodySize(64))
+
+	var tooLarge ErrorResponse
+	status := doRequest(t, server, http.MethodPost, "/Users", map[string]any{
+		"userName":    "alice@example.com",
+		"displayName": strings.Repeat("a", 128),
+	}, &tooLarge)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, status)
+	assert.Equal(t, "413", tooLarge.Status)
+}
+
+// failingBackend is a Backend returning an internal error for every user lookup.
+type failingBackend struct {
+	*MemoryBackend
+}
+
+func (b failingBackend) GetUser(context.Context, string) (*User, error) {
+	return nil, errors.New("connection to db-internal.example.com refused")
+}
+
+func TestHandler_InternalError(t *testing.T) {
+	var logs bytes.Buffer
+
+	server := newTestServerWithBackend(t, failingBackend{MemoryBackend: NewMemoryBackend()}, "secret", WithLogger(slog.New(slog.NewTextHandler(&logs, nil))))
+
+	var internal ErrorResponse
+	status := doRequest(t, server, http.MethodGet, "/Users/u1", nil, &internal)
+	assert.Equal(t, http.StatusInternalServerError, status)
+	assert.Equal(t, "internal server error", internal.Detail)
+	assert.Contains(t, logs.String(), "db-internal.example.com")
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
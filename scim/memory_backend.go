@@ -0,0 +1,329 @@
+package scim
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+var _ Backend = (*MemoryBackend)(nil)
+
+// MemoryBackend is an in-memory Backend.
+// It can be used to test SCIM clients and identity provider configurations without a Raito backend.
+// User names and group display names are unique, compared case-insensitively.
+// Group members must be existing users or groups.
+type MemoryBackend struct {
+	mutex sync.RWMutex
+
+	users  map[string]*User
+	groups map[string]*Group
+	order  []string
+}
+
+func NewMemoryBackend() *MemoryBackend {
+	return &MemoryBackend{
+		users:  make(map[string]*User),
+		groups: make(map[string]*Group),
+	}
+}
+
+func (b *MemoryBackend) GetUser(_ context.Context, id string) (*User, error) {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	user, found := b.users[id]
+	if !found {
+		return nil, fmt.Errorf("%w: user %q", ErrNotFound, id)
+	}
+
+	return b.userWithGroups(user), nil
+}
+
+func (b *MemoryBackend) ListUsers(_ context.Context, filter *Filter) ([]User, error) {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	var users []User
+
+	for _, id := range b.order {
+		if user, found := b.users[id]; found {
+			result := b.userWithGroups(user)
+			if filter.Match(result) {
+				users = append(users, *result)
+			}
+		}
+	}
+
+	return users, nil
+}
+
+func (b *MemoryBackend) CreateUser(_ context.Context, user *User) (*User, error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	err := b.checkUserName(user, "")
+	if err != nil {
+		return nil, err
+	}
+
+	created := cloneUser(user)
+	created.Id = newId()
+	created.Groups = nil
+	created.Meta = newMeta(nil)
+
+	b.users[created.Id] = created
+	b.order = append(b.order, created.Id)
+
+	return b.userWithGroups(created), nil
+}
+
+func (b *MemoryBackend) ReplaceUser(_ context.Context, user *User) (*User, error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	existing, found := b.users[user.Id]
+	if !found {
+		return nil, fmt.Errorf("%w: user %q", ErrNotFound, user.Id)
+	}
+
+	err := b.checkUserName(user, user.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	replaced := cloneUser(user)
+	replaced.Groups = nil
+	replaced.Meta = newMeta(existing.Meta)
+
+	b.users[replaced.Id] = replaced
+
+	return b.userWithGroups(replaced), nil
+}
+
+func (b *MemoryBackend) DeleteUser(_ context.Context, id string) error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if _, found := b.users[id]; !found {
+		return fmt.Errorf("%w: user %q", ErrNotFound, id)
+	}
+
+	delete(b.users, id)
+	b.removeMember(id)
+
+	return nil
+}
+
+func (b *MemoryBackend) GetGroup(_ context.Context, id string) (*Group, error) {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	group, found := b.groups[id]
+	if !found {
+		return nil, fmt.Errorf("%w: group %q", ErrNotFound, id)
+	}
+
+	return cloneGroup(group), nil
+}
+
+func (b *MemoryBackend) ListGroups(_ context.Context, filter *Filter) ([]Group, error) {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	var groups []Group
+
+	for _, id := range b.order {
+		if group, found := b.groups[id]; found && filter.Match(group) {
+			groups = append(groups, *cloneGroup(group))
+		}
+	}
+
+	return groups, nil
+}
+
+func (b *MemoryBackend) CreateGroup(_ context.Context, group *Group) (*Group, error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	err := b.checkGroup(group, "")
+	if err != nil {
+		return nil, err
+	}
+
+	created := cloneGroup(group)
+	created.Id = newId()
+	created.Meta = newMeta(nil)
+
+	b.groups[created.Id] = created
+	b.order = append(b.order, created.Id)
+
+	return cloneGroup(created), nil
+}
+
+func (b *MemoryBackend) ReplaceGroup(_ context.Context, group *Group) (*Group, error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	existing, found := b.groups[group.Id]
+	if !found {
+		return nil, fmt.Errorf("%w: group %q", ErrNotFound, group.Id)
+	}
+
+	err := b.checkGroup(group, group.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	replaced := cloneGroup(group)
+	replaced.Meta = newMeta(existing.Meta)
+
+	b.groups[replaced.Id] = replaced
+
+	return cloneGroup(replaced), nil
+}
+
+func (b *MemoryBackend) DeleteGroup(_ context.Context, id string) error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if _, found := b.groups[id]; !found {
+		return fmt.Errorf("%w: group %q", ErrNotFound, id)
+	}
+
+	delete(b.groups, id)
+	b.removeMember(id)
+
+	return nil
+}
+
+func (b *MemoryBackend) checkUserName(user *User, id string) error {
+	if user.UserName == "" {
+		return fmt.Errorf("%w: userName is required", ErrInvalidValue)
+	}
+
+	for existingId, existing := range b.users {
+		if existingId != id && strings.EqualFold(existing.UserName, user.UserName) {
+			return fmt.Errorf("%w: user %q", ErrConflict, user.UserName)
+		}
+	}
+
+	return nil
+}
+
+func (b *MemoryBackend) checkGroup(group *Group, id string) error {
+	if group.DisplayName == "" {
+		return fmt.Errorf("%w: displayName is required", ErrInvalidValue)
+	}
+
+	for existingId, existing := range b.groups {
+		if existingId != id && strings.EqualFold(existing.DisplayName, group.DisplayName) {
+			return fmt.Errorf("%w: group %q", ErrConflict, group.DisplayName)
+		}
+	}
+
+	for _, member := range group.Members {
+		_, isUser := b.users[member.Value]
+		_, isGroup := b.groups[member.Value]
+
+		if (!isUser && !isGroup) || member.Value == id {
+			return fmt.Errorf("%w: unknown member %q", ErrInvalidValue, member.Value)
+		}
+	}
+
+	return nil
+}
+
+func (b *MemoryBackend) removeMember(id string) {
+	for _, group := range b.groups {
+		members := group.Members[:0]
+
+		for _, member := range group.Members {
+			if member.Value != id {
+				members = append(members, member)
+			}
+		}
+
+		group.Members = members
+	}
+}
+
+// userWithGroups returns a copy of the user with the groups it is a direct member of.
+func (b *MemoryBackend) userWithGroups(user *User) *User {
+	result := cloneUser(user)
+
+	for _, id := range b.order {
+		group, found := b.groups[id]
+		if !found {
+			continue
+		}
+
+		for _, member := range group.Members {
+			if member.Value == user.Id {
+				result.Groups = append(result.Groups, GroupRef{Value: group.Id, Display: group.DisplayName})
+
+				break
+			}
+		}
+	}
+
+	return result
+}
+
+func cloneUser(user *User) *User {
+	result := *user
+
+	if user.Name != nil {
+		name := *user.Name
+		result.Name = &name
+	}
+
+	if user.Active != nil {
+		active := *user.Active
+		result.Active = &active
+	}
+
+	if user.Meta != nil {
+		meta := *user.Meta
+		result.Meta = &meta
+	}
+
+	result.Emails = append([]MultiValue(nil), user.Emails...)
+	result.Groups = append([]GroupRef(nil), user.Groups...)
+
+	return &result
+}
+
+func cloneGroup(group *Group) *Group {
+	result := *group
+
+	if group.Meta != nil {
+		meta := *group.Meta
+		result.Meta = &meta
+	}
+
+	result.Members = append([]Member(nil), group.Members...)
+
+	return &result
+}
+
+func newMeta(existing *Meta) *Meta {
+	now := time.Now()
+
+	meta := Meta{Created: &now, LastModified: &now}
+	if existing != nil && existing.Created != nil {
+		meta.Created = existing.Created
+	}
+
+	return &meta
+}
+
+func newId() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,292 @@
+package scim
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ApplyPatch applies the PATCH operations to the resource and stores the result in target.
+// The operations are applied to the JSON representation of the resource, following RFC 7644, section 3.5.2.
+// Attribute names are matched case-insensitively. Boolean values sent as strings, like "False", are converted to booleans.
+// Returns an error wrapping ErrInvalidPath or ErrInvalidValue if an operation cannot be applied.
+func ApplyPatch(resource any, operations []PatchOperation, target any) error {
+	value, err := toJSONValue(resource)
+	if err != nil {
+		return err
+	}
+
+	object, ok := value.(map[string]any)
+	if !ok {
+		return fmt.Errorf("%w: resource is not an object", ErrInvalidValue)
+	}
+
+	for i := range operations {
+		err = applyPatchOperation(object, &operations[i])
+		if err != nil {
+			return fmt.Errorf("operation %d: %w", i, err)
+		}
+	}
+
+	data, err := json.Marshal(object)
+	if err != nil {
+		return err
+	}
+
+	err = json.Unmarshal(data, target)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidValue, err.Error())
+	}
+
+	return nil
+}
+
+type patchPath struct {
+	attribute    string
+	filter       *Filter
+	subAttribute string
+}
+
+func parsePatchPath(path string) (*patchPath, error) {
+	result := patchPath{}
+
+	if start := strings.Index(path, "["); start >= 0 {
+		end := strings.LastIndex(path, "]")
+		if end < start {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidPath, path)
+		}
+
+		filter, err := ParseFilter(path[start+1 : end])
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q: %s", ErrInvalidPath, path, err.Error())
+		}
+
+		result.filter = filter
+		result.subAttribute = strings.TrimPrefix(path[end+1:], ".")
+		path = path[:start]
+	}
+
+	names := attributePath(path)
+
+	switch {
+	case len(names) == 1 && names[0] != "":
+		result.attribute = names[0]
+	case len(names) == 2 && result.filter == nil:
+		result.attribute = names[0]
+		result.subAttribute = names[1]
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrInvalidPath, path)
+	}
+
+	return &result, nil
+}
+
+func applyPatchOperation(object map[string]any, operation *PatchOperation) error {
+	op := strings.ToLower(operation.Op)
+
+	switch op {
+	case "add", "replace", "remove":
+	default:
+		return fmt.Errorf("%w: unknown operation %q", ErrInvalidValue, operation.Op)
+	}
+
+	if operation.Path == "" {
+		if op == "remove" {
+			return fmt.Errorf("%w: remove requires a path", ErrInvalidPath)
+		}
+
+		values, ok := operation.Value.(map[string]any)
+		if !ok {
+			return fmt.Errorf("%w: %s without path requires an object value", ErrInvalidValue, op)
+		}
+
+		for name, value := range values {
+			err := applyPatchOperation(object, &PatchOperation{Op: op, Path: name, Value: value})
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	path, err := parsePatchPath(operation.Path)
+	if err != nil {
+		return err
+	}
+
+	key, _ := findKey(object, path.attribute)
+	value := normalizePatchValue(path, operation.Value)
+
+	if path.filter != nil {
+		return applyFilteredPatchOperation(object, key, path, op, value)
+	}
+
+	if path.subAttribute != "" {
+		parent, ok := object[key].(map[string]any)
+		if !ok {
+			if op == "remove" {
+				return nil
+			}
+
+			parent = make(map[string]any)
+			object[key] = parent
+		}
+
+		subKey, _ := findKey(parent, path.subAttribute)
+
+		if op == "remove" {
+			delete(parent, subKey)
+		} else {
+			parent[subKey] = value
+		}
+
+		return nil
+	}
+
+	existing, isList := object[key].([]any)
+
+	switch op {
+	case "add":
+		if isList {
+			object[key] = appendMultiValues(existing, flatten(value))
+		} else {
+			object[key] = value
+		}
+	case "replace":
+		object[key] = value
+	case "remove":
+		if isList && value != nil {
+			object[key] = removeMultiValues(existing, flatten(value))
+		} else {
+			delete(object, key)
+		}
+	}
+
+	return nil
+}
+
+func applyFilteredPatchOperation(object map[string]any, key string, path *patchPath, op string, value any) error {
+	existing, _ := object[key].([]any)
+	result := make([]any, 0, len(existing))
+	matched := false
+
+	for _, item := range existing {
+		if !path.filter.expr.match(item) {
+			result = append(result, item)
+
+			continue
+		}
+
+		matched = true
+
+		element, isObject := item.(map[string]any)
+
+		switch {
+		case op == "remove" && path.subAttribute == "":
+			continue
+		case !isObject:
+			return fmt.Errorf("%w: %q does not contain complex values", ErrInvalidPath, path.attribute)
+		case path.subAttribute != "":
+			subKey, _ := findKey(element, path.subAttribute)
+
+			if op == "remove" {
+				delete(element, subKey)
+			} else {
+				element[subKey] = value
+			}
+		default:
+			values, ok := value.(map[string]any)
+			if !ok {
+				return fmt.Errorf("%w: %s of %q requires an object value", ErrInvalidValue, op, path.attribute)
+			}
+
+			for name, v := range values {
+				subKey, _ := findKey(element, name)
+				element[subKey] = v
+			}
+		}
+
+		result = append(result, element)
+	}
+
+	if !matched && op != "add" {
+		return fmt.Errorf("%w: no values of %q match the filter", ErrInvalidPath, path.attribute)
+	}
+
+	object[key] = result
+
+	return nil
+}
+
+// normalizePatchValue converts boolean attributes sent as strings, as done by some identity providers.
+func normalizePatchValue(path *patchPath, value any) any {
+	s, ok := value.(string)
+	if !ok {
+		return value
+	}
+
+	name := path.attribute
+	if path.subAttribute != "" {
+		name = path.subAttribute
+	}
+
+	if !strings.EqualFold(name, "active") && !strings.EqualFold(name, "primary") {
+		return value
+	}
+
+	b, err := strconv.ParseBool(s)
+	if err != nil {
+		return value
+	}
+
+	return b
+}
+
+func appendMultiValues(existing []any, values []any) []any {
+	for _, value := range values {
+		if !containsMultiValue(existing, value) {
+			existing = append(existing, value)
+		}
+	}
+
+	return existing
+}
+
+func removeMultiValues(existing []any, values []any) []any {
+	result := make([]any, 0, len(existing))
+
+	for _, item := range existing {
+		if !containsMultiValue(values, item) {
+			result = append(result, item)
+		}
+	}
+
+	return result
+}
+
+func containsMultiValue(values []any, value any) bool {
+	needle := multiValueKey(value)
+
+	for _, v := range values {
+		if multiValueKey(v) == needle {
+			return true
+		}
+	}
+
+	return false
+}
+
+// multiValueKey identifies a value of a multi-valued attribute by its value sub-attribute.
+func multiValueKey(value any) string {
+	if object, ok := value.(map[string]any); ok {
+		if key, found := findKey(object, "value"); found {
+			value = object[key]
+		}
+	}
+
+	data, _ := json.Marshal(value)
+
+	return string(data)
+}
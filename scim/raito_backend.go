@@ -0,0 +1,352 @@
+package scim
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/aws/smithy-go/ptr"
+
+	"github.com/raito-io/sdk-go/services"
+	"github.com/raito-io/sdk-go/types"
+)
+
+type RaitoBackendOptions struct {
+	deleteOnDeactivate bool
+	isMember           func(ctx context.Context, user *types.User) (bool, error)
+}
+
+// WithRaitoBackendDeleteOnDeactivate deletes a user from Raito when the identity provider deactivates it.
+// By default, deactivating a user is not supported, as Raito users have no active flag.
+func WithRaitoBackendDeleteOnDeactivate() func(options *RaitoBackendOptions) {
+	return func(options *RaitoBackendOptions) {
+		options.deleteOnDeactivate = true
+	}
+}
+
+// WithRaitoBackendUserMembership sets the function deciding whether a Raito user is a member of the configured identity store.
+// The Raito API has no lookup of the users of an identity store, so the user routes are not supported without this option.
+// Users that are not a member are reported as not found.
+func WithRaitoBackendUserMembership(isMember func(ctx context.Context, user *types.User) (bool, error)) func(options *RaitoBackendOptions) {
+	return func(options *RaitoBackendOptions) {
+		options.isMember = isMember
+	}
+}
+
+var _ Backend = (*RaitoBackend)(nil)
+
+// RaitoBackend is a Backend that provisions into Raito using the UserClient, GroupClient and IdentityStoreClient.
+// All users and groups are scoped to the configured identity store. Resources outside of it are reported as not found.
+//
+// The Raito API limits what the RaitoBackend supports:
+//   - Users are identified by their email address, which is used as SCIM userName.
+//   - The user routes require a membership check set with WithRaitoBackendUserMembership, as Raito cannot list the users of an identity store.
+//   - Users can only be created if the configured identity store is the native Raito identity store.
+//   - Listing users requires an equality filter on id, userName or emails, as Raito has no user listing.
+//   - Groups are read-only and returned without members. Creating, replacing, patching and deleting groups is not supported.
+//
+// Unsupported operations return ErrNotSupported, which the Handler returns as 501 Not Implemented.
+type RaitoBackend struct {
+	users           *services.UserClient
+	groups          *services.GroupClient
+	identityStores  *services.IdentityStoreClient
+	identityStoreId string
+	options         RaitoBackendOptions
+}
+
+func NewRaitoBackend(users *services.UserClient, groups *services.GroupClient, identityStores *services.IdentityStoreClient, identityStoreId string, ops ...func(options *RaitoBackendOptions)) *RaitoBackend {
+	options := RaitoBackendOptions{}
+	for _, op := range ops {
+		op(&options)
+	}
+
+	return &RaitoBackend{
+		users:           users,
+		groups:          groups,
+		identityStores:  identityStores,
+		identityStoreId: identityStoreId,
+		options:         options,
+	}
+}
+
+func (b *RaitoBackend) GetUser(ctx context.Context, id string) (*User, error) {
+	user, err := b.getUser(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return fromRaitoUser(user), nil
+}
+
+func (b *RaitoBackend) ListUsers(ctx context.Context, filter *Filter) ([]User, error) {
+	if b.options.isMember == nil {
+		return nil, b.errUsersNotSupported()
+	}
+
+	attribute, value, ok := filter.Equality()
+	if !ok {
+		return nil, fmt.Errorf("%w: listing users requires an equality filter on id, userName or emails", ErrNotSupported)
+	}
+
+	var user *types.User
+	var err error
+
+	switch strings.ToLower(attribute) {
+	case "id":
+		user, err = b.users.GetUser(ctx, value)
+	case "username", "emails", "emails.value":
+		user, err = b.users.GetUserByEmail(ctx, value)
+	default:
+		return nil, fmt.Errorf("%w: listing users requires an equality filter on id, userName or emails", ErrNotSupported)
+	}
+
+	var notFound *types.ErrNotFound
+	if errors.As(err, &notFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, raitoError(err)
+	}
+
+	err = b.checkMember(ctx, user)
+	if errors.Is(err, ErrNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	return []User{*fromRaitoUser(user)}, nil
+}
+
+func (b *RaitoBackend) CreateUser(ctx context.Context, user *User) (*User, error) {
+	if b.options.isMember == nil {
+		return nil, b.errUsersNotSupported()
+	}
+
+	if !user.IsActive() {
+		return nil, fmt.Errorf("%w: creating an inactive user", ErrNotSupported)
+	}
+
+	identityStore, err := b.identityStores.GetIdentityStore(ctx, b.identityStoreId)
+	if err != nil {
+		return nil, raitoError(err)
+	}
+
+	if !identityStore.Native {
+		return nil, fmt.Errorf("%w: users can only be created in the native identity store, %q is not native", ErrNotSupported, b.identityStoreId)
+	}
+
+	created, err := b.users.CreateUser(ctx, toRaitoUserInput(user))
+	if err != nil {
+		return nil, raitoError(err)
+	}
+
+	return fromRaitoUser(created), nil
+}
+
+func (b *RaitoBackend) ReplaceUser(ctx context.Context, user *User) (*User, error) {
+	_, err := b.getUser(ctx, user.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !user.IsActive() {
+		if !b.options.deleteOnDeactivate {
+			return nil, fmt.Errorf("%w: deactivating a user", ErrNotSupported)
+		}
+
+		err = b.users.DeleteUser(ctx, user.Id)
+		if err != nil {
+			return nil, raitoError(err)
+		}
+
+		return user, nil
+	}
+
+	updated, err := b.users.UpdateUser(ctx, user.Id, toRaitoUserInput(user))
+	if err != nil {
+		return nil, raitoError(err)
+	}
+
+	return fromRaitoUser(updated), nil
+}
+
+func (b *RaitoBackend) DeleteUser(ctx context.Context, id string) error {
+	_, err := b.getUser(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	err = b.users.DeleteUser(ctx, id)
+	if err != nil {
+		return raitoError(err)
+	}
+
+	return nil
+}
+
+func (b *RaitoBackend) GetGroup(ctx context.Context, id string) (*Group, error) {
+	groups, err := b.ListGroups(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range groups {
+		if groups[i].Id == id {
+			return &groups[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: group %q in identity store %q", ErrNotFound, id, b.identityStoreId)
+}
+
+func (b *RaitoBackend) ListGroups(ctx context.Context, _ *Filter) ([]Group, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var groups []Group
+
+	for item := range b.identityStores.ListIdentityStoreGroups(ctx, b.identityStoreId) {
+		if item.HasError() {
+			return nil, raitoError(item.GetError())
+		}
+
+		group := item.GetItem()
+		if group.Deleted {
+			continue
+		}
+
+		groups = append(groups, *fromRaitoGroup(group))
+	}
+
+	return groups, nil
+}
+
+func (b *RaitoBackend) CreateGroup(context.Context, *Group) (*Group, error) {
+	return nil, fmt.Errorf("%w: creating groups", ErrNotSupported)
+}
+
+func (b *RaitoBackend) ReplaceGroup(context.Context, *Group) (*Group, error) {
+	return nil, fmt.Errorf("%w: updating groups", ErrNotSupported)
+}
+
+func (b *RaitoBackend) DeleteGroup(context.Context, string) error {
+	return fmt.Errorf("%w: deleting groups", ErrNotSupported)
+}
+
+// getUser returns the Raito user with the given id if it is a member of the configured identity store.
+func (b *RaitoBackend) getUser(ctx context.Context, id string) (*types.User, error) {
+	if b.options.isMember == nil {
+		return nil, b.errUsersNotSupported()
+	}
+
+	user, err := b.users.GetUser(ctx, id)
+	if err != nil {
+		return nil, raitoError(err)
+	}
+
+	err = b.checkMember(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (b *RaitoBackend) checkMember(ctx context.Context, user *types.User) error {
+	member, err := b.options.isMember(ctx, user)
+	if err != nil {
+		return fmt.Errorf("check membership of user %q: %w", user.Id, err)
+	}
+
+	if !member {
+		return fmt.Errorf("%w: user %q in identity store %q", ErrNotFound, user.Id, b.identityStoreId)
+	}
+
+	return nil
+}
+
+func (b *RaitoBackend) errUsersNotSupported() error {
+	return fmt.Errorf("%w: users cannot be scoped to identity store %q without a membership check", ErrNotSupported, b.identityStoreId)
+}
+
+func fromRaitoUser(user *types.User) *User {
+	email := ptr.ToString(user.Email)
+
+	result := User{
+		Schemas:     []string{UserSchema},
+		Id:          user.Id,
+		UserName:    email,
+		DisplayName: user.Name,
+		Name:        &Name{Formatted: user.Name},
+		Active:      ptr.Bool(true),
+	}
+
+	if email != "" {
+		result.Emails = []MultiValue{{Value: email, Primary: true}}
+	}
+
+	return &result
+}
+
+func toRaitoUserInput(user *User) types.UserInput {
+	email := user.PrimaryEmail()
+	if email == "" {
+		email = user.UserName
+	}
+
+	name := user.DisplayName
+
+	if name == "" && user.Name != nil {
+		name = user.Name.Formatted
+
+		if name == "" {
+			name = strings.TrimSpace(user.Name.GivenName + " " + user.Name.FamilyName)
+		}
+	}
+
+	if name == "" {
+		name = user.UserName
+	}
+
+	userType := types.UserTypeHuman
+
+	return types.UserInput{
+		Name:  ptr.String(name),
+		Email: ptr.String(email),
+		Type:  &userType,
+	}
+}
+
+func fromRaitoGroup(group *types.Group) *Group {
+	displayName := group.DisplayName
+	if displayName == "" {
+		displayName = group.Name
+	}
+
+	return &Group{
+		Schemas:     []string{GroupSchema},
+		Id:          group.Id,
+		ExternalId:  group.Name,
+		DisplayName: displayName,
+	}
+}
+
+// raitoError wraps the errors of the Raito services into the matching Backend errors.
+func raitoError(err error) error {
+	var notFound *types.ErrNotFound
+	var alreadyExists *types.ErrAlreadyExists
+	var invalidInput *types.ErrInvalidInput
+	var invalidEmail *types.ErrInvalidEmail
+
+	switch {
+	case errors.As(err, &notFound):
+		return fmt.Errorf("%w: %s", ErrNotFound, err.Error())
+	case errors.As(err, &alreadyExists):
+		return fmt.Errorf("%w: %s", ErrConflict, err.Error())
+	case errors.As(err, &invalidInput), errors.As(err, &invalidEmail):
+		return fmt.Errorf("%w: %s", ErrInvalidValue, err.Error())
+	default:
+		return err
+	}
+}
@@ -0,0 +1,184 @@
+package scim
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/raito-io/sdk-go/services"
+	"github.com/raito-io/sdk-go/types"
+)
+
+// fakeGraphqlClient is a graphql.Client that answers requests with the JSON returned by the handler registered for the operation.
+type fakeGraphqlClient struct {
+	mutex    sync.Mutex
+	handlers map[string]func(variables map[string]any) string
+	requests map[string]int
+}
+
+func (c *fakeGraphqlClient) MakeRequest(_ context.Context, req *graphql.Request, resp *graphql.Response) error {
+	c.mutex.Lock()
+	c.requests[req.OpName]++
+	handler, found := c.handlers[req.OpName]
+	c.mutex.Unlock()
+
+	if !found {
+		return fmt.Errorf("unexpected operation %q", req.OpName)
+	}
+
+	data, err := json.Marshal(req.Variables)
+	if err != nil {
+		return err
+	}
+
+	variables := make(map[string]any)
+
+	err = json.Unmarshal(data, &variables)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal([]byte(handler(variables)), resp.Data)
+}
+
+func newTestRaitoBackend(native bool, ops ...func(options *RaitoBackendOptions)) (*RaitoBackend, *fakeGraphqlClient) {
+	client := &fakeGraphqlClient{
+		requests: make(map[string]int),
+		handlers: map[string]func(variables map[string]any) string{
+			"GetUser": func(variables map[string]any) string {
+				return fmt.Sprintf(`{"user":{"__typename":"User","id":"%[1]s","name":"User %[1]s","email":"%[1]s@example.com"}}`, variables["id"])
+			},
+			"GetIdentityStore": func(map[string]any) string {
+				return fmt.Sprintf(`{"identityStore":{"__typename":"IdentityStore","id":"is1","native":%t}}`, native)
+			},
+			"CreateUser": func(map[string]any) string {
+				return `{"createUser":{"__typename":"User","id":"u1","name":"User u1","email":"u1@example.com"}}`
+			},
+			"DeleteUser": func(map[string]any) string {
+				return `{"deleteUser":{"__typename":"UserDelete","success":true}}`
+			},
+			"ListGroups": func(map[string]any) string {
+				return `{"groups":{"__typename":"PagedResult","pageInfo":{"hasNextPage":false},"edges":[` +
+					`{"cursor":"0","node":{"__typename":"Group","id":"g1","name":"group1"}},` +
+					`{"cursor":"1","node":{"__typename":"Group","id":"g2","name":"group2","deleted":true}}]}}`
+			},
+		},
+	}
+
+	users := services.NewUserClient(client)
+	groups := services.NewGroupClient(client)
+	identityStores := services.NewIdentityStoreClient(client)
+
+	backend := NewRaitoBackend(&users, &groups, &identityStores, "is1", ops...)
+
+	return backend, client
+}
+
+func mustParseFilter(t *testing.T, filter string) *Filter {
+	t.Helper()
+
+	parsed, err := ParseFilter(filter)
+	require.NoError(t, err)
+
+	return parsed
+}
+
+func TestRaitoBackend_UserMembership(t *testing.T) {
+	ctx := context.Background()
+
+	isMember := WithRaitoBackendUserMembership(func(_ context.Context, user *types.User) (bool, error) {
+		return user.Id == "u1", nil
+	})
+
+	t.Run("without membership check", func(t *testing.T) {
+		backend, client := newTestRaitoBackend(true)
+
+		_, err := backend.GetUser(ctx, "u1")
+		require.ErrorIs(t, err, ErrNotSupported)
+
+		_, err = backend.CreateUser(ctx, &User{UserName: "u1@example.com"})
+		require.ErrorIs(t, err, ErrNotSupported)
+
+		assert.Empty(t, client.requests)
+	})
+
+	t.Run("member", func(t *testing.T) {
+		backend, client := newTestRaitoBackend(true, isMember)
+
+		user, err := backend.GetUser(ctx, "u1")
+		require.NoError(t, err)
+		assert.Equal(t, "u1@example.com", user.UserName)
+
+		users, err := backend.ListUsers(ctx, mustParseFilter(t, `id eq "u1"`))
+		require.NoError(t, err)
+		assert.Len(t, users, 1)
+
+		require.NoError(t, backend.DeleteUser(ctx, "u1"))
+		assert.Equal(t, 1, client.requests["DeleteUser"])
+	})
+
+	t.Run("not a member", func(t *testing.T) {
+		backend, client := newTestRaitoBackend(true, isMember)
+
+		_, err := backend.GetUser(ctx, "u2")
+		require.ErrorIs(t, err, ErrNotFound)
+
+		users, err := backend.ListUsers(ctx, mustParseFilter(t, `id eq "u2"`))
+		require.NoError(t, err)
+		assert.Empty(t, users)
+
+		_, err = backend.ReplaceUser(ctx, &User{Id: "u2", UserName: "u2@example.com"})
+		require.ErrorIs(t, err, ErrNotFound)
+
+		require.ErrorIs(t, backend.DeleteUser(ctx, "u2"), ErrNotFound)
+		assert.Zero(t, client.requests["DeleteUser"])
+	})
+
+	t.Run("listing without equality filter", func(t *testing.T) {
+		backend, _ := newTestRaitoBackend(true, isMember)
+
+		_, err := backend.ListUsers(ctx, nil)
+		require.ErrorIs(t, err, ErrNotSupported)
+	})
+}
+
+func TestRaitoBackend_CreateUser(t *testing.T) {
+	ctx := context.Background()
+	isMember := WithRaitoBackendUserMembership(func(context.Context, *types.User) (bool, error) { return true, nil })
+
+	backend, _ := newTestRaitoBackend(true, isMember)
+
+	created, err := backend.CreateUser(ctx, &User{UserName: "u1@example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, "u1", created.Id)
+
+	backend, client := newTestRaitoBackend(false, isMember)
+
+	_, err = backend.CreateUser(ctx, &User{UserName: "u1@example.com"})
+	require.ErrorIs(t, err, ErrNotSupported)
+	assert.Zero(t, client.requests["CreateUser"])
+}
+
+func TestRaitoBackend_Groups(t *testing.T) {
+	ctx := context.Background()
+	backend, _ := newTestRaitoBackend(true)
+
+	group, err := backend.GetGroup(ctx, "g1")
+	require.NoError(t, err)
+	assert.Equal(t, "group1", group.DisplayName)
+
+	_, err = backend.GetGroup(ctx, "g2")
+	require.ErrorIs(t, err, ErrNotFound)
+
+	_, err = backend.GetGroup(ctx, "other")
+	require.ErrorIs(t, err, ErrNotFound)
+
+	_, err = backend.CreateGroup(ctx, &Group{DisplayName: "group3"})
+	require.ErrorIs(t, err, ErrNotSupported)
+}
@@ -2,8 +2,12 @@ package services
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/Khan/genqlient/graphql"
 	"github.com/aws/smithy-go/ptr"
@@ -11,6 +15,7 @@ import (
 	"github.com/raito-io/sdk-go/internal"
 	"github.com/raito-io/sdk-go/internal/schema"
 	"github.com/raito-io/sdk-go/types"
+	"github.com/raito-io/sdk-go/types/models"
 )
 
 type AccessProviderClient struct {
@@ -23,10 +28,35 @@ func NewAccessProviderClient(client graphql.Client) AccessProviderClient {
 	}
 }
 
+type CreateAccessProviderOptions struct {
+	skipCapabilityCheck bool
+}
+
+// WithCreateAccessProviderSkipCapabilityCheck skips the check that the data sources of masks and filters support column masking or row filtering.
+func WithCreateAccessProviderSkipCapabilityCheck() func(options *CreateAccessProviderOptions) {
+	return func(options *CreateAccessProviderOptions) {
+		options.skipCapabilityCheck = true
+	}
+}
+
 // CreateAccessProvider creates a new AccessProvider in Raito Cloud.
+// Masks and filters are rejected with a types.ErrInvalidInput, before they are sent, if one of their data sources cannot enforce them.
+// Use WithCreateAccessProviderSkipCapabilityCheck to skip this check.
 // The valid AccessProvider is returned if the creation is successful.
 // Otherwise, an error is returned
-func (a *AccessProviderClient) CreateAccessProvider(ctx context.Context, ap types.AccessProviderInput) (*types.AccessProvider, error) {
+func (a *AccessProviderClient) CreateAccessProvider(ctx context.Context, ap types.AccessProviderInput, ops ...func(options *CreateAccessProviderOptions)) (*types.AccessProvider, error) {
+	options := CreateAccessProviderOptions{}
+	for _, op := range ops {
+		op(&options)
+	}
+
+	if !options.skipCapabilityCheck {
+		err := checkAccessProviderDataSourceFeatures(ctx, a.client, &ap)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	result, err := schema.CreateAccessProvider(ctx, a.client, ap)
 	if err != nil {
 		return nil, types.NewErrClient(err)
@@ -47,7 +77,8 @@ func (a *AccessProviderClient) CreateAccessProvider(ctx context.Context, ap type
 }
 
 type UpdateAccessProviderOptions struct {
-	overrideLocks bool
+	overrideLocks   bool
+	unmodifiedSince *time.Time
 }
 
 func WithAccessProviderOverrideLocks() func(options *UpdateAccessProviderOptions) {
@@ -56,8 +87,26 @@ func WithAccessProviderOverrideLocks() func(options *UpdateAccessProviderOptions
 	}
 }
 
+// WithAccessProviderIfUnmodifiedSince only updates the AccessProvider if it was not modified after t.
+// Pass the modifiedAt of the AccessProvider that was read to detect concurrent updates.
+// If the AccessProvider was modified after t, a types.ErrConflict is returned.
+// The check is best-effort: the client reads the current modifiedAt before sending the update,
+// so a concurrent write between that read and the update is not detected and can still be overwritten.
+func WithAccessProviderIfUnmodifiedSince(t time.Time) func(options *UpdateAccessProviderOptions) {
+	return func(options *UpdateAccessProviderOptions) {
+		options.unmodifiedSince = &t
+	}
+}
+
+// WithAccessProviderExpectedVersion only updates the AccessProvider if its current version is the expected version.
+// The version of a AccessProvider is its modifiedAt, so this is equivalent to WithAccessProviderIfUnmodifiedSince(modifiedAt).
+func WithAccessProviderExpectedVersion(modifiedAt time.Time) func(options *UpdateAccessProviderOptions) {
+	return WithAccessProviderIfUnmodifiedSince(modifiedAt)
+}
+
 // UpdateAccessProvider updates an existing AccessProvider in Raito Cloud.
 // The updated AccessProvider is returned if the update is successful.
+// WithAccessProviderIfUnmodifiedSince can be used to detect most concurrent modifications, but does not guarantee protection against them.
 // Otherwise, an error is returned.
 func (a *AccessProviderClient) UpdateAccessProvider(ctx context.Context, id string, ap schema.AccessProviderInput, ops ...func(options *UpdateAccessProviderOptions)) (*types.AccessProvider, error) {
 	options := UpdateAccessProviderOptions{}
@@ -65,6 +114,18 @@ func (a *AccessProviderClient) UpdateAccessProvider(ctx context.Context, id stri
 		op(&options)
 	}
 
+	if options.unmodifiedSince != nil {
+		current, err := a.GetAccessProvider(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		err = checkUnmodifiedSince("accessProvider", id, current.ModifiedAt, options.unmodifiedSince)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	result, err := schema.UpdateAccessProvider(ctx, a.client, id, ap, &options.overrideLocks)
 	if err != nil {
 		return nil, types.NewErrClient(err)
@@ -215,19 +276,106 @@ func (a *AccessProviderClient) ListAccessProviders(ctx context.Context, ops ...f
 		}
 	}
 
-	edgeFn := func(edge *schema.AccessProviderPageEdgesEdge) (*string, *schema.AccessProvider, error) {
-		cursor := edge.Cursor
+	return internal.PaginationExecutor(ctx, loadPageFn, accessProvidersEdgeFn)
+}
+
+// ListAccessProvidersByTag returns a list of AccessProviders that have all the given tags.
+// The tag filters are added to the filter set with WithAccessProviderListFilter, if any.
+// A channel is returned that can be used to receive the list of AccessProviders.
+// To close the channel ensure to cancel the context.
+func (a *AccessProviderClient) ListAccessProvidersByTag(ctx context.Context, tags []types.TagFilter, ops ...func(*AccessProviderListOptions)) <-chan types.ListItem[types.AccessProvider] {
+	options := AccessProviderListOptions{}
+	for _, op := range ops {
+		op(&options)
+	}
 
-		if edge.Node == nil {
-			return cursor, nil, nil
+	filter := types.AccessProviderFilterInput{}
+	if options.filter != nil {
+		filter = *options.filter
+	}
+
+	filter.HasTags = append(append([]types.TagFilter{}, filter.HasTags...), tags...)
+
+	return a.ListAccessProviders(ctx, WithAccessProviderListOrder(options.order...), WithAccessProviderListFilter(&filter))
+}
+
+// ListAccessProviderTags returns the tags of the AccessProvider with the given id.
+func (a *AccessProviderClient) ListAccessProviderTags(ctx context.Context, id string) ([]types.Tag, error) {
+	result, err := schema.GetAccessProviderTags(ctx, a.client, id)
+	if err != nil {
+		return nil, types.NewErrClient(err)
+	}
+
+	switch ap := result.AccessProvider.(type) {
+	case *schema.GetAccessProviderTagsAccessProvider:
+		tags := make([]types.Tag, 0, len(ap.Tags))
+		for i := range ap.Tags {
+			tags = append(tags, ap.Tags[i].Tag)
 		}
 
-		listItem := (*edge.Node).(*schema.AccessProviderPageEdgesEdgeNodeAccessProvider)
+		return tags, nil
+	case *schema.GetAccessProviderTagsAccessProviderNotFoundError:
+		return nil, types.NewErrNotFound(id, ap.Typename, ap.Message)
+	case *schema.GetAccessProviderTagsAccessProviderPermissionDeniedError:
+		return nil, types.NewErrPermissionDenied("getAccessProviderTags", ap.Message)
+	default:
+		return nil, fmt.Errorf("unexpected response type: %T", result.AccessProvider)
+	}
+}
+
+// GetAccessProviderOwners returns the ids of the users and groups that own the AccessProvider with the given id.
+// Ownership is modelled as an assignment of the owner role (see RoleClient.GetOwnerRole) on the AccessProvider.
+func (a *AccessProviderClient) GetAccessProviderOwners(ctx context.Context, id string) ([]string, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	roleClient := NewRoleClient(a.client)
 
-		return cursor, &listItem.AccessProvider, nil
+	ownerRole, err := roleClient.GetOwnerRole(ctx)
+	if err != nil {
+		return nil, err
 	}
 
-	return internal.PaginationExecutor(ctx, loadPageFn, edgeFn)
+	return roleAssigneeIds(roleClient.ListRoleAssignmentsOnAccessProvider(ctx, id, WithRoleAssignmentListFilter(&types.RoleAssignmentFilterInput{Role: ptr.String(ownerRole.Id)})))
+}
+
+// SetAccessProviderOwners sets the owners of the AccessProvider with the given id.
+// owners is a list of user and group ids. Existing owners that are not in the list are removed.
+func (a *AccessProviderClient) SetAccessProviderOwners(ctx context.Context, id string, owners ...string) error {
+	roleClient := NewRoleClient(a.client)
+
+	ownerRole, err := roleClient.GetOwnerRole(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = roleClient.UpdateRoleAssigneesOnAccessProvider(ctx, id, ownerRole.Id, owners...)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ListOwnedAccessProviders returns a list of AccessProviders owned by the given user.
+// The order of the list can be specified with WithAccessProviderListOrder.
+// Additional filters can be specified with WithAccessProviderListFilter.
+// A channel is returned that can be used to receive the list of AccessProviders.
+// To close the channel ensure to cancel the context.
+func (a *AccessProviderClient) ListOwnedAccessProviders(ctx context.Context, userId string, ops ...func(*AccessProviderListOptions)) <-chan types.ListItem[types.AccessProvider] {
+	options := AccessProviderListOptions{}
+	for _, op := range ops {
+		op(&options)
+	}
+
+	filter := types.AccessProviderFilterInput{}
+	if options.filter != nil {
+		filter = *options.filter
+	}
+
+	filter.Owners = []string{userId}
+
+	return a.ListAccessProviders(ctx, WithAccessProviderListOrder(options.order...), WithAccessProviderListFilter(&filter))
 }
 
 type AccessProviderWhoListOptions struct {
@@ -276,21 +424,151 @@ func (a *AccessProviderClient) GetAccessProviderWhoList(ctx context.Context, id
 		return nil, nil, errors.New("unreachable")
 	}
 
-	edgeFn := func(edge *types.AccessProviderWhoListEdgesEdge) (*string, *schema.AccessProviderWhoListItem, error) {
-		cursor := edge.Cursor
+	return internal.PaginationExecutor(ctx, loadPageFn, accessProviderWhoListEdgeFn)
+}
+
+// ExpiringWhoItem is a who item of an access provider that expires.
+// ExpiresAt is the zero time for who items that expire relative to the moment they were granted (ExpiresAfter),
+// which are only returned with WithExpiringWhoItemListRelativeExpiry.
+type ExpiringWhoItem struct {
+	AccessProviderId   string
+	AccessProviderName string
+	WhoItem            types.AccessProviderWhoListItem
+	ExpiresAt          time.Time
+	ExpiresAfter       *int64
+}
 
-		if edge.Node == nil {
-			return cursor, nil, nil
+type ExpiringWhoItemListOptions struct {
+	filter         *types.AccessProviderFilterInput
+	relativeExpiry bool
+}
+
+// WithExpiringWhoItemListFilter can be used to filter the access providers that are checked in the ListExpiringWhoItems call.
+func WithExpiringWhoItemListFilter(input *types.AccessProviderFilterInput) func(options *ExpiringWhoItemListOptions) {
+	return func(options *ExpiringWhoItemListOptions) {
+		options.filter = input
+	}
+}
+
+// WithExpiringWhoItemListRelativeExpiry also returns the who items with a relative expiry (ExpiresAfter) in the ListExpiringWhoItems call.
+// The moment those who items were granted is not part of the who list, so they are returned regardless of the given duration.
+func WithExpiringWhoItemListRelativeExpiry() func(options *ExpiringWhoItemListOptions) {
+	return func(options *ExpiringWhoItemListOptions) {
+		options.relativeExpiry = true
+	}
+}
+
+type expiringWhoItemEdge struct {
+	cursor string
+	item   *ExpiringWhoItem
+}
+
+// ListExpiringWhoItems returns all who items of AccessProviders that expire within the given duration from now.
+// Who items that are already expired, but not yet removed, are included as well.
+// By default, only who items with an absolute expiry date (ExpiresAt) are reported. Who items that expire a duration after they are
+// granted (ExpiresAfter) are skipped, as the moment they were granted is not part of the who list.
+// Use WithExpiringWhoItemListRelativeExpiry to return them as well.
+// The AccessProviders and their who lists are streamed, so at most a page of AccessProviders and the expiring who items
+// of a single AccessProvider are kept in memory.
+// The checked AccessProviders can be filtered with WithExpiringWhoItemListFilter.
+// A channel is returned that can be used to receive the list of ExpiringWhoItem.
+// To close the channel ensure to cancel the context.
+func (a *AccessProviderClient) ListExpiringWhoItems(ctx context.Context, within time.Duration, ops ...func(options *ExpiringWhoItemListOptions)) <-chan types.ListItem[ExpiringWhoItem] {
+	options := ExpiringWhoItemListOptions{}
+	for _, op := range ops {
+		op(&options)
+	}
+
+	deadline := time.Now().Add(within)
+
+	// accessProviders is read one AccessProvider per page, so the AccessProviders are loaded while the who items are consumed.
+	var accessProviders <-chan types.ListItem[types.AccessProvider]
+
+	loadPageFn := func(ctx context.Context, cursor *string) (*types.PageInfo, []expiringWhoItemEdge, error) {
+		if cursor == nil {
+			accessProviders = a.ListAccessProviders(ctx, WithAccessProviderListFilter(options.filter))
+		}
+
+		var apItem types.ListItem[types.AccessProvider]
+
+		select {
+		case <-ctx.Done():
+			return nil, nil, ctx.Err()
+		case item, ok := <-accessProviders:
+			if !ok {
+				return &types.PageInfo{HasNextPage: ptr.Bool(false)}, nil, nil
+			}
+
+			apItem = item
 		}
 
-		listItem := (*edge.Node).(*types.AccessProviderWhoListEdgesEdgeNodeAccessWhoItem)
+		if apItem.HasError() {
+			return nil, nil, apItem.GetError()
+		}
+
+		ap := apItem.GetItem()
 
-		return cursor, &listItem.AccessProviderWhoListItem, nil
+		edges, err := a.expiringWhoItemEdges(ctx, ap, deadline, options.relativeExpiry, ap.Id)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return &types.PageInfo{HasNextPage: ptr.Bool(true)}, edges, nil
+	}
+
+	edgeFn := func(edge *expiringWhoItemEdge) (*string, *ExpiringWhoItem, error) {
+		return &edge.cursor, edge.item, nil
 	}
 
 	return internal.PaginationExecutor(ctx, loadPageFn, edgeFn)
 }
 
+func (a *AccessProviderClient) listAccessProviders(ctx context.Context, filter *types.AccessProviderFilterInput) ([]types.AccessProvider, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	return internal.CollectListItems(a.ListAccessProviders(ctx, WithAccessProviderListFilter(filter)))
+}
+
+// expiringWhoItemEdges returns the who items of the access provider that expire before the deadline.
+// Who items with a relative expiry are included if relativeExpiry is set.
+// At least one edge is returned, so the cursor always moves to the next access provider.
+func (a *AccessProviderClient) expiringWhoItemEdges(ctx context.Context, ap *types.AccessProvider, deadline time.Time, relativeExpiry bool, cursor string) ([]expiringWhoItemEdge, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	edges := []expiringWhoItemEdge{{cursor: cursor}}
+
+	for whoItem := range a.GetAccessProviderWhoList(ctx, ap.Id) {
+		if whoItem.HasError() {
+			return nil, whoItem.GetError()
+		}
+
+		item := whoItem.GetItem()
+
+		expiring := &ExpiringWhoItem{
+			AccessProviderId:   ap.Id,
+			AccessProviderName: ap.Name,
+			WhoItem:            *item,
+			ExpiresAfter:       item.ExpiresAfter,
+		}
+
+		if item.ExpiresAt != nil {
+			if item.ExpiresAt.After(deadline) {
+				continue
+			}
+
+			expiring.ExpiresAt = *item.ExpiresAt
+		} else if item.ExpiresAfter == nil || !relativeExpiry {
+			continue
+		}
+
+		edges = append(edges, expiringWhoItemEdge{cursor: cursor, item: expiring})
+	}
+
+	return edges, nil
+}
+
 type AccessProviderWhatListOptions struct {
 	order  []types.AccessWhatOrderByInput
 	filter *types.AccessWhatFilterInput
@@ -345,19 +623,7 @@ func (a *AccessProviderClient) GetAccessProviderWhatDataObjectList(ctx context.C
 		return nil, nil, errors.New("unreachable")
 	}
 
-	edgeFn := func(edge *types.AccessProviderWhatListEdgesEdge) (*string, *schema.AccessProviderWhatListItem, error) {
-		cursor := edge.Cursor
-
-		if edge.Node == nil {
-			return cursor, nil, nil
-		}
-
-		listItem := (*edge.Node).(*types.AccessProviderWhatListEdgesEdgeNodeAccessWhatItem)
-
-		return cursor, &listItem.AccessProviderWhatListItem, nil
-	}
-
-	return internal.PaginationExecutor(ctx, loadPageFn, edgeFn)
+	return internal.PaginationExecutor(ctx, loadPageFn, accessProviderWhatListEdgeFn)
 }
 
 // AccessProviderWhatAccessProviderListOptions options for listing what access providers of an AccessProvider in Raito Cloud.
@@ -412,19 +678,7 @@ func (a *AccessProviderClient) GetAccessProviderWhatAccessProviderList(ctx conte
 		}
 	}
 
-	edgeFn := func(edge *types.AccessProviderWhatAccessProviderListEdgesEdge) (*string, *types.AccessWhatAccessProviderItem, error) {
-		cursor := edge.Cursor
-
-		if edge.Node == nil {
-			return cursor, nil, nil
-		}
-
-		listItem := (*edge.Node).(*types.AccessProviderWhatAccessProviderListEdgesEdgeNodeAccessWhatAccessProviderItem)
-
-		return cursor, &listItem.AccessWhatAccessProviderItem, nil
-	}
-
-	return internal.PaginationExecutor(ctx, loadPageFn, edgeFn)
+	return internal.PaginationExecutor(ctx, loadPageFn, accessProviderWhatAccessProviderListEdgeFn)
 }
 
 type AccessProviderAbacWhatScopeListOptions struct {
@@ -481,17 +735,579 @@ func (a *AccessProviderClient) GetAccessProviderAbacWhatScope(ctx context.Contex
 		}
 	}
 
-	edgeFn := func(edge *types.AccessProviderWhatAbacScopeListEdgesEdge) (*string, *types.DataObject, error) {
-		cursor := edge.Cursor
+	return internal.PaginationExecutor(ctx, loadPageFn, accessProviderAbacWhatScopeEdgeFn)
+}
 
-		if edge.Node == nil {
-			return cursor, nil, nil
+// AddWhoItems adds who items to an existing AccessProvider.
+// A who item that refers to a principal that is already part of the AccessProvider replaces the existing who item.
+// The current who items are loaded, patched and written back. If the AccessProvider was modified in the meantime, the patch is retried.
+// The patch is not atomic: a concurrent write between the modification check and the update is lost.
+func (a *AccessProviderClient) AddWhoItems(ctx context.Context, id string, items []types.WhoItemInput, ops ...func(options *UpdateAccessProviderOptions)) (*types.AccessProvider, error) {
+	return a.patchAccessProvider(ctx, id, func(input *types.AccessProviderInput) error {
+		if input.WhoType != nil && *input.WhoType == types.WhoAndWhatTypeDynamic {
+			return types.NewErrInvalidInput("who items can not be added to an access provider with a dynamic who")
 		}
 
-		listItem := (*edge.Node).(*types.AccessProviderWhatAbacScopeListEdgesEdgeNodeDataObject)
+		input.WhoItems = mergeWhoItems(input.WhoItems, items)
+
+		return nil
+	}, ops...)
+}
 
-		return cursor, &listItem.DataObject, nil
+// RemoveWhoItems removes who items from an existing AccessProvider.
+// Who items are matched on the principal they refer to (user, group, access provider, data source or recipient).
+// The current who items are loaded, patched and written back. If the AccessProvider was modified in the meantime, the patch is retried.
+// The patch is not atomic: a concurrent write between the modification check and the update is lost.
+func (a *AccessProviderClient) RemoveWhoItems(ctx context.Context, id string, items []types.WhoItemInput, ops ...func(options *UpdateAccessProviderOptions)) (*types.AccessProvider, error) {
+	toRemove := make(map[string]struct{}, len(items))
+	for i := range items {
+		toRemove[whoItemKey(&items[i])] = struct{}{}
 	}
 
-	return internal.PaginationExecutor(ctx, loadPageFn, edgeFn)
+	return a.patchAccessProvider(ctx, id, func(input *types.AccessProviderInput) error {
+		whoItems := make([]types.WhoItemInput, 0, len(input.WhoItems))
+
+		for i := range input.WhoItems {
+			if _, found := toRemove[whoItemKey(&input.WhoItems[i])]; !found {
+				whoItems = append(whoItems, input.WhoItems[i])
+			}
+		}
+
+		input.WhoItems = whoItems
+
+		return nil
+	}, ops...)
+}
+
+// AddWhatDataObjects adds what data objects to an existing AccessProvider.
+// Data objects that are already part of the AccessProvider get the permissions of the new what item.
+// The current what items are loaded, patched and written back. If the AccessProvider was modified in the meantime, the patch is retried.
+// The patch is not atomic: a concurrent write between the modification check and the update is lost.
+func (a *AccessProviderClient) AddWhatDataObjects(ctx context.Context, id string, items []types.AccessProviderWhatInputDO, ops ...func(options *UpdateAccessProviderOptions)) (*types.AccessProvider, error) {
+	added := make(map[string]struct{})
+
+	for i := range items {
+		for _, doId := range items[i].DataObjects {
+			if doId != nil {
+				added[*doId] = struct{}{}
+			}
+		}
+	}
+
+	return a.patchAccessProvider(ctx, id, func(input *types.AccessProviderInput) error {
+		if input.WhatType != nil && *input.WhatType == types.WhoAndWhatTypeDynamic {
+			return types.NewErrInvalidInput("what data objects can not be added to an access provider with a dynamic what")
+		}
+
+		input.WhatDataObjects = append(removeWhatDataObjects(input.WhatDataObjects, added), items...)
+
+		return nil
+	}, ops...)
+}
+
+// RemoveWhatDataObjects removes what data objects from an existing AccessProvider.
+// dataObjectIds are the ids of the data objects to remove.
+// The current what items are loaded, patched and written back. If the AccessProvider was modified in the meantime, the patch is retried.
+// The patch is not atomic: a concurrent write between the modification check and the update is lost.
+func (a *AccessProviderClient) RemoveWhatDataObjects(ctx context.Context, id string, dataObjectIds []string, ops ...func(options *UpdateAccessProviderOptions)) (*types.AccessProvider, error) {
+	toRemove := make(map[string]struct{}, len(dataObjectIds))
+	for _, doId := range dataObjectIds {
+		toRemove[doId] = struct{}{}
+	}
+
+	return a.patchAccessProvider(ctx, id, func(input *types.AccessProviderInput) error {
+		input.WhatDataObjects = removeWhatDataObjects(input.WhatDataObjects, toRemove)
+
+		return nil
+	}, ops...)
+}
+
+// AddWhatAccessProviders adds what access providers to an existing AccessProvider.
+// An access provider that is already part of the what of the AccessProvider is replaced.
+// The current what items are loaded, patched and written back. If the AccessProvider was modified in the meantime, the patch is retried.
+// The patch is not atomic: a concurrent write between the modification check and the update is lost.
+func (a *AccessProviderClient) AddWhatAccessProviders(ctx context.Context, id string, items []types.AccessProviderWhatInputAP, ops ...func(options *UpdateAccessProviderOptions)) (*types.AccessProvider, error) {
+	added := make(map[string]struct{}, len(items))
+	for i := range items {
+		added[items[i].AccessProvider] = struct{}{}
+	}
+
+	return a.patchAccessProvider(ctx, id, func(input *types.AccessProviderInput) error {
+		input.WhatAccessProviders = append(removeWhatAccessProviders(input.WhatAccessProviders, added), items...)
+
+		return nil
+	}, ops...)
+}
+
+// RemoveWhatAccessProviders removes what access providers from an existing AccessProvider.
+// accessProviderIds are the ids of the access providers to remove.
+// The current what items are loaded, patched and written back. If the AccessProvider was modified in the meantime, the patch is retried.
+// The patch is not atomic: a concurrent write between the modification check and the update is lost.
+func (a *AccessProviderClient) RemoveWhatAccessProviders(ctx context.Context, id string, accessProviderIds []string, ops ...func(options *UpdateAccessProviderOptions)) (*types.AccessProvider, error) {
+	toRemove := make(map[string]struct{}, len(accessProviderIds))
+	for _, apId := range accessProviderIds {
+		toRemove[apId] = struct{}{}
+	}
+
+	return a.patchAccessProvider(ctx, id, func(input *types.AccessProviderInput) error {
+		input.WhatAccessProviders = removeWhatAccessProviders(input.WhatAccessProviders, toRemove)
+
+		return nil
+	}, ops...)
+}
+
+// ExtendWhoItemExpiry sets the expiry date of the who item of the given principal on an AccessProvider.
+// principal identifies the who item by its user, group, access provider or data source; other fields are ignored.
+// A types.ErrNotFound is returned if the principal is not a who item of the AccessProvider.
+// The current who items are loaded, patched and written back. If the AccessProvider was modified in the meantime, the patch is retried.
+// The patch is not atomic: a concurrent write between the modification check and the update is lost.
+func (a *AccessProviderClient) ExtendWhoItemExpiry(ctx context.Context, id string, principal types.WhoItemInput, newExpiry time.Time, ops ...func(options *UpdateAccessProviderOptions)) (*types.AccessProvider, error) {
+	return a.patchAccessProvider(ctx, id, func(input *types.AccessProviderInput) error {
+		whoItem, err := findWhoItem(input.WhoItems, id, &principal)
+		if err != nil {
+			return err
+		}
+
+		whoItem.ExpiresAt = &newExpiry
+		whoItem.ExpiresAfter = nil
+
+		return nil
+	}, ops...)
+}
+
+// ConvertPromiseToGrant converts the promise who item of the given principal on an AccessProvider into a grant.
+// If the promise has a promise duration, the grant expires after that duration.
+// principal identifies the who item by its user, group, access provider or data source; other fields are ignored.
+// A types.ErrNotFound is returned if the principal is not a who item of the AccessProvider, a types.ErrInvalidInput if the who item is not a promise.
+// The current who items are loaded, patched and written back. If the AccessProvider was modified in the meantime, the patch is retried.
+// The patch is not atomic: a concurrent write between the modification check and the update is lost.
+func (a *AccessProviderClient) ConvertPromiseToGrant(ctx context.Context, id string, principal types.WhoItemInput, ops ...func(options *UpdateAccessProviderOptions)) (*types.AccessProvider, error) {
+	return a.patchAccessProvider(ctx, id, func(input *types.AccessProviderInput) error {
+		whoItem, err := findWhoItem(input.WhoItems, id, &principal)
+		if err != nil {
+			return err
+		}
+
+		if whoItem.Type == nil || *whoItem.Type != types.AccessWhoItemTypeWhopromise {
+			return types.NewErrInvalidInput(fmt.Sprintf("who item %q of access provider %q is not a promise", whoItemKey(&principal), id))
+		}
+
+		grant := types.AccessWhoItemTypeWhogrant
+
+		whoItem.Type = &grant
+		whoItem.ExpiresAt = nil
+		whoItem.ExpiresAfter = whoItem.PromiseDuration
+		whoItem.PromiseDuration = nil
+
+		return nil
+	}, ops...)
+}
+
+// patchAccessProvider loads the current state of an AccessProvider, applies patchFn and writes the result back.
+// The update is done with WithAccessProviderIfUnmodifiedSince. On a types.ErrConflict, the patch is retried with the new state,
+// up to internal.MaxPatchAttempts times. After that, the last types.ErrConflict is returned.
+// The patch is not atomic: the modifiedAt check and the update are separate requests,
+// so a concurrent write that lands between them is overwritten without a conflict being reported.
+func (a *AccessProviderClient) patchAccessProvider(ctx context.Context, id string, patchFn func(input *types.AccessProviderInput) error, ops ...func(options *UpdateAccessProviderOptions)) (*types.AccessProvider, error) {
+	var conflictErr *types.ErrConflict
+
+	for range internal.MaxPatchAttempts {
+		ap, err := a.GetAccessProvider(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		input, err := a.accessProviderInput(ctx, ap)
+		if err != nil {
+			return nil, fmt.Errorf("load current state of access provider %q: %w", id, err)
+		}
+
+		err = patchFn(input)
+		if err != nil {
+			return nil, err
+		}
+
+		updateOps := make([]func(options *UpdateAccessProviderOptions), 0, len(ops)+1)
+		updateOps = append(updateOps, ops...)
+		updateOps = append(updateOps, WithAccessProviderIfUnmodifiedSince(ap.ModifiedAt))
+
+		result, err := a.UpdateAccessProvider(ctx, id, *input, updateOps...)
+		if errors.As(err, &conflictErr) {
+			continue
+		}
+
+		return result, err
+	}
+
+	return nil, conflictErr
+}
+
+// accessProviderInput builds the AccessProviderInput that represents the current state of an AccessProvider.
+// Filters are refused, as their FilterCriteria and CommonWhatDataObjectId can not be read back.
+// Source can not be read back either and is not part of the input.
+// The relative expiry (ExpiresAfter) of a who item is only written back if the who item has no absolute expiry yet,
+// so the expiry of an active who item is not restarted.
+func (a *AccessProviderClient) accessProviderInput(ctx context.Context, ap *types.AccessProvider) (*types.AccessProviderInput, error) {
+	if ap.Action == models.AccessProviderActionFiltered {
+		return nil, types.NewErrInvalidInput("the filter criteria and common data object of a filter can not be loaded")
+	}
+
+	action := ap.Action
+	whoType := ap.WhoType
+	whatType := ap.WhatType
+	external := ap.External
+
+	input := types.AccessProviderInput{
+		Name:        ptr.String(ap.Name),
+		NamingHint:  ap.NamingHint,
+		Action:      &action,
+		Description: ptr.String(ap.Description),
+		WhoType:     &whoType,
+		WhatType:    &whatType,
+		PolicyRule:  ap.PolicyRule,
+		External:    &external,
+	}
+
+	if ap.Category != nil {
+		input.Category = ptr.String(ap.Category.Id)
+	}
+
+	for i := range ap.Locks {
+		lock := types.AccessProviderLockDataInput{LockKey: ap.Locks[i].LockKey}
+
+		if ap.Locks[i].Details.Reason != nil {
+			lock.Details = &types.AccessProviderLockDetailsInput{Reason: ap.Locks[i].Details.Reason}
+		}
+
+		input.Locks = append(input.Locks, lock)
+	}
+
+	for i := range ap.SyncData {
+		dsInput := types.AccessProviderDataSourceInput{DataSource: ap.SyncData[i].DataSource.Id}
+
+		if ap.SyncData[i].MaskType != nil {
+			dsInput.Type = ptr.String(ap.SyncData[i].MaskType.ExternalId)
+		} else if ap.SyncData[i].AccessProviderType != nil {
+			dsInput.Type = ap.SyncData[i].AccessProviderType.Type
+		}
+
+		input.DataSources = append(input.DataSources, dsInput)
+	}
+
+	err := a.loadWhoInput(ctx, ap, &input)
+	if err != nil {
+		return nil, err
+	}
+
+	err = a.loadWhatInput(ctx, ap, &input)
+	if err != nil {
+		return nil, err
+	}
+
+	return &input, nil
+}
+
+func (a *AccessProviderClient) loadWhoInput(ctx context.Context, ap *types.AccessProvider, input *types.AccessProviderInput) error {
+	if ap.WhoType == types.WhoAndWhatTypeDynamic {
+		if ap.WhoAbacRule == nil {
+			return nil
+		}
+
+		rule, err := abacRuleInput(ap.WhoAbacRule.RuleJson)
+		if err != nil {
+			return err
+		}
+
+		input.WhoAbacRule = &types.WhoAbacRuleInput{
+			Rule:            rule,
+			Type:            ap.WhoAbacRule.Type,
+			PromiseDuration: ap.WhoAbacRule.PromiseDuration,
+		}
+
+		return nil
+	}
+
+	whoItems, err := internal.CollectListItems(a.GetAccessProviderWhoList(ctx, ap.Id))
+	if err != nil {
+		return err
+	}
+
+	input.WhoItems = make([]types.WhoItemInput, 0, len(whoItems))
+
+	for i := range whoItems {
+		whoItem, whoErr := whoItemInputFromListItem(&whoItems[i])
+		if whoErr != nil {
+			return whoErr
+		}
+
+		input.WhoItems = append(input.WhoItems, whoItem)
+	}
+
+	return nil
+}
+
+func (a *AccessProviderClient) loadWhatInput(ctx context.Context, ap *types.AccessProvider, input *types.AccessProviderInput) error {
+	if ap.WhatType == types.WhoAndWhatTypeDynamic {
+		if ap.WhatAbacRule == nil {
+			return nil
+		}
+
+		rule, err := abacRuleInput(ap.WhatAbacRule.RuleJson)
+		if err != nil {
+			return err
+		}
+
+		scope, err := internal.CollectListItems(a.GetAccessProviderAbacWhatScope(ctx, ap.Id))
+		if err != nil {
+			return err
+		}
+
+		input.WhatAbacRule = &types.WhatAbacRuleInput{
+			DoTypes:           ap.WhatAbacRule.DoTypes,
+			Permissions:       ap.WhatAbacRule.Permissions,
+			GlobalPermissions: ap.WhatAbacRule.GlobalPermissions,
+			Scope:             make([]string, 0, len(scope)),
+			Rule:              rule,
+		}
+
+		for i := range scope {
+			input.WhatAbacRule.Scope = append(input.WhatAbacRule.Scope, scope[i].Id)
+		}
+	} else {
+		whatItems, err := internal.CollectListItems(a.GetAccessProviderWhatDataObjectList(ctx, ap.Id))
+		if err != nil {
+			return err
+		}
+
+		input.WhatDataObjects = whatDataObjectInputs(whatItems)
+	}
+
+	whatAccessProviders, err := internal.CollectListItems(a.GetAccessProviderWhatAccessProviderList(ctx, ap.Id))
+	if err != nil {
+		return err
+	}
+
+	input.WhatAccessProviders = make([]types.AccessProviderWhatInputAP, 0, len(whatAccessProviders))
+
+	for i := range whatAccessProviders {
+		if whatAccessProviders[i].AccessProvider == nil {
+			continue
+		}
+
+		input.WhatAccessProviders = append(input.WhatAccessProviders, types.AccessProviderWhatInputAP{
+			AccessProvider: whatAccessProviders[i].AccessProvider.Id,
+			ExpiresAt:      whatAccessProviders[i].ExpiresAt,
+		})
+	}
+
+	return nil
+}
+
+func abacRuleInput(ruleJson *string) (types.AbacComparisonExpressionInput, error) {
+	var rule types.AbacComparisonExpressionInput
+
+	if ruleJson == nil {
+		return rule, nil
+	}
+
+	err := json.Unmarshal([]byte(*ruleJson), &rule)
+	if err != nil {
+		return rule, fmt.Errorf("parse abac rule: %w", err)
+	}
+
+	return rule, nil
+}
+
+func whoItemInputFromListItem(item *types.AccessProviderWhoListItem) (types.WhoItemInput, error) {
+	whoType := item.Type
+
+	input := types.WhoItemInput{
+		Type:            &whoType,
+		PromiseDuration: item.PromiseDuration,
+	}
+
+	if item.ExpiresAt != nil {
+		input.ExpiresAt = item.ExpiresAt
+	} else {
+		input.ExpiresAfter = item.ExpiresAfter
+	}
+
+	switch who := item.Item.(type) {
+	case *types.AccessProviderWhoListItemItemUser:
+		input.User = ptr.String(who.Id)
+	case *types.AccessProviderWhoListItemItemGroup:
+		input.Group = ptr.String(who.Id)
+	case *types.AccessProviderWhoListItemItemAccessProvider:
+		input.AccessProvider = ptr.String(who.Id)
+	case *types.AccessProviderWhoListItemItemDataSource:
+		input.DataSource = ptr.String(who.Id)
+	case *types.AccessProviderWhoListItemItemDataShareRecipient:
+		// The who list does not return the id of a data share recipient, so the who item can not be written back.
+		return input, types.NewErrInvalidInput("access providers with data share recipients in the who list can not be patched")
+	default:
+		return input, fmt.Errorf("unsupported who item '%T': %w", who, types.ErrUnknownType)
+	}
+
+	return input, nil
+}
+
+// whoItemKey returns a key that identifies the principal of a who item.
+func whoItemKey(item *types.WhoItemInput) string {
+	switch {
+	case item.User != nil:
+		return "user:" + *item.User
+	case item.Group != nil:
+		return "group:" + *item.Group
+	case item.AccessProvider != nil:
+		return "accessProvider:" + *item.AccessProvider
+	case item.DataSource != nil:
+		return "dataSource:" + *item.DataSource
+	case item.Recipient != nil:
+		return "recipient:" + *item.Recipient
+	default:
+		return ""
+	}
+}
+
+// findWhoItem returns the who item of the given principal.
+func findWhoItem(items []types.WhoItemInput, apId string, principal *types.WhoItemInput) (*types.WhoItemInput, error) {
+	key := whoItemKey(principal)
+	if key == "" {
+		return nil, types.NewErrInvalidInput("no principal set in who item")
+	}
+
+	for i := range items {
+		if whoItemKey(&items[i]) == key {
+			return &items[i], nil
+		}
+	}
+
+	return nil, types.NewErrNotFound(key, ptr.String("AccessWhoItem"), fmt.Sprintf("who item not found on access provider %q", apId))
+}
+
+func mergeWhoItems(current []types.WhoItemInput, added []types.WhoItemInput) []types.WhoItemInput {
+	addedKeys := make(map[string]struct{}, len(added))
+	for i := range added {
+		addedKeys[whoItemKey(&added[i])] = struct{}{}
+	}
+
+	result := make([]types.WhoItemInput, 0, len(current)+len(added))
+
+	for i := range current {
+		if _, found := addedKeys[whoItemKey(&current[i])]; !found {
+			result = append(result, current[i])
+		}
+	}
+
+	return append(result, added...)
+}
+
+// whatDataObjectInputs groups what list items with the same permissions in a single AccessProviderWhatInputDO.
+func whatDataObjectInputs(items []types.AccessProviderWhatListItem) []types.AccessProviderWhatInputDO {
+	result := make([]types.AccessProviderWhatInputDO, 0, len(items))
+	indexByPermissions := make(map[string]int)
+
+	for i := range items {
+		if items[i].DataObject == nil {
+			continue
+		}
+
+		key := permissionsKey(items[i].Permissions) + "|" + permissionsKey(items[i].GlobalPermissions)
+
+		idx, found := indexByPermissions[key]
+		if !found {
+			idx = len(result)
+			indexByPermissions[key] = idx
+
+			result = append(result, types.AccessProviderWhatInputDO{
+				Permissions:       items[i].Permissions,
+				GlobalPermissions: items[i].GlobalPermissions,
+			})
+		}
+
+		result[idx].DataObjects = append(result[idx].DataObjects, ptr.String(items[i].DataObject.Id))
+	}
+
+	return result
+}
+
+func permissionsKey(permissions []*string) string {
+	keys := make([]string, 0, len(permissions))
+
+	for _, p := range permissions {
+		if p != nil {
+			keys = append(keys, *p)
+		}
+	}
+
+	sort.Strings(keys)
+
+	return strings.Join(keys, ",")
+}
+
+func removeWhatDataObjects(items []types.AccessProviderWhatInputDO, toRemove map[string]struct{}) []types.AccessProviderWhatInputDO {
+	result := make([]types.AccessProviderWhatInputDO, 0, len(items))
+
+	for i := range items {
+		item := items[i]
+		item.DataObjects = make([]*string, 0, len(items[i].DataObjects))
+
+		for _, doId := range items[i].DataObjects {
+			if doId == nil {
+				continue
+			}
+
+			if _, found := toRemove[*doId]; !found {
+				item.DataObjects = append(item.DataObjects, doId)
+			}
+		}
+
+		if len(item.DataObjects) > 0 || len(item.DataObjectByName) > 0 {
+			result = append(result, item)
+		}
+	}
+
+	return result
+}
+
+func removeWhatAccessProviders(items []types.AccessProviderWhatInputAP, toRemove map[string]struct{}) []types.AccessProviderWhatInputAP {
+	result := make([]types.AccessProviderWhatInputAP, 0, len(items))
+
+	for i := range items {
+		if _, found := toRemove[items[i].AccessProvider]; !found {
+			result = append(result, items[i])
+		}
+	}
+
+	return result
+}
+
+func accessProvidersEdgeFn(edge *schema.AccessProviderPageEdgesEdge) (*string, *schema.AccessProvider, error) {
+	return internal.DecodeEdgeNode(edge.Cursor, edge.Node, func(node *schema.AccessProviderPageEdgesEdgeNodeAccessProvider) *schema.AccessProvider {
+		return &node.AccessProvider
+	})
+}
+
+func accessProviderWhoListEdgeFn(edge *types.AccessProviderWhoListEdgesEdge) (*string, *schema.AccessProviderWhoListItem, error) {
+	return internal.DecodeEdgeNode(edge.Cursor, edge.Node, func(node *types.AccessProviderWhoListEdgesEdgeNodeAccessWhoItem) *schema.AccessProviderWhoListItem {
+		return &node.AccessProviderWhoListItem
+	})
+}
+
+func accessProviderWhatListEdgeFn(edge *types.AccessProviderWhatListEdgesEdge) (*string, *schema.AccessProviderWhatListItem, error) {
+	return internal.DecodeEdgeNode(edge.Cursor, edge.Node, func(node *types.AccessProviderWhatListEdgesEdgeNodeAccessWhatItem) *schema.AccessProviderWhatListItem {
+		return &node.AccessProviderWhatListItem
+	})
+}
+
+func accessProviderWhatAccessProviderListEdgeFn(edge *types.AccessProviderWhatAccessProviderListEdgesEdge) (*string, *types.AccessWhatAccessProviderItem, error) {
+	return internal.DecodeEdgeNode(edge.Cursor, edge.Node, func(node *types.AccessProviderWhatAccessProviderListEdgesEdgeNodeAccessWhatAccessProviderItem) *types.AccessWhatAccessProviderItem {
+		return &node.AccessWhatAccessProviderItem
+	})
+}
+
+func accessProviderAbacWhatScopeEdgeFn(edge *types.AccessProviderWhatAbacScopeListEdgesEdge) (*string, *types.DataObject, error) {
+	return internal.DecodeEdgeNode(edge.Cursor, edge.Node, func(node *types.AccessProviderWhatAbacScopeListEdgesEdgeNodeDataObject) *types.DataObject {
+		return &node.DataObject
+	})
 }
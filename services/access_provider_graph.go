@@ -0,0 +1,425 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+
+	"github.com/raito-io/sdk-go/internal"
+	"github.com/raito-io/sdk-go/types"
+	"github.com/raito-io/sdk-go/types/models"
+)
+
+type AccessProviderGraphEdgeType string
+
+const (
+	// AccessProviderGraphEdgeTypeWhoInheritance is an edge from an access provider to an access provider that has it as who item.
+	AccessProviderGraphEdgeTypeWhoInheritance AccessProviderGraphEdgeType = "WhoInheritance"
+	// AccessProviderGraphEdgeTypeWhatInheritance is an edge from an access provider to an access provider in its what.
+	AccessProviderGraphEdgeTypeWhatInheritance AccessProviderGraphEdgeType = "WhatInheritance"
+)
+
+// AccessProviderGraphNode is an access provider in the AccessProviderGraph.
+// WhoItemCount is the number of who items of the access provider that are not access providers.
+// Access providers with a dynamic WhoType get their who items from a who rule, which are not counted in WhoItemCount.
+type AccessProviderGraphNode struct {
+	Id           string                      `json:"id"`
+	Name         string                      `json:"name"`
+	Action       models.AccessProviderAction `json:"action"`
+	State        models.AccessProviderState  `json:"state"`
+	WhoType      types.WhoAndWhatType        `json:"whoType"`
+	WhoItemCount int                         `json:"whoItemCount"`
+}
+
+// AccessProviderGraphEdge is a directed edge in the AccessProviderGraph.
+// In both edge types, the who items of From receive the access of To.
+type AccessProviderGraphEdge struct {
+	From string                      `json:"from"`
+	To   string                      `json:"to"`
+	Type AccessProviderGraphEdgeType `json:"type"`
+}
+
+// AccessProviderGraph is the inheritance graph between access providers.
+type AccessProviderGraph struct {
+	nodes    map[string]*AccessProviderGraphNode
+	outgoing map[string][]AccessProviderGraphEdge
+	incoming map[string][]AccessProviderGraphEdge
+}
+
+func newAccessProviderGraph() *AccessProviderGraph {
+	return &AccessProviderGraph{
+		nodes:    make(map[string]*AccessProviderGraphNode),
+		outgoing: make(map[string][]AccessProviderGraphEdge),
+		incoming: make(map[string][]AccessProviderGraphEdge),
+	}
+}
+
+func (g *AccessProviderGraph) addNode(node AccessProviderGraphNode) {
+	g.nodes[node.Id] = &node
+}
+
+func (g *AccessProviderGraph) addEdge(edge AccessProviderGraphEdge) {
+	for _, existing := range g.outgoing[edge.From] {
+		if existing == edge {
+			return
+		}
+	}
+
+	g.outgoing[edge.From] = append(g.outgoing[edge.From], edge)
+	g.incoming[edge.To] = append(g.incoming[edge.To], edge)
+}
+
+// Node returns the node with the given id, or nil if it is not part of the graph.
+func (g *AccessProviderGraph) Node(id string) *AccessProviderGraphNode {
+	return g.nodes[id]
+}
+
+// Nodes returns all nodes of the graph, sorted by id.
+func (g *AccessProviderGraph) Nodes() []AccessProviderGraphNode {
+	result := make([]AccessProviderGraphNode, 0, len(g.nodes))
+	for _, id := range g.sortedIds() {
+		result = append(result, *g.nodes[id])
+	}
+
+	return result
+}
+
+// Edges returns all edges of the graph, sorted by source and target.
+func (g *AccessProviderGraph) Edges() []AccessProviderGraphEdge {
+	var result []AccessProviderGraphEdge
+	for _, id := range g.sortedIds() {
+		result = append(result, g.outgoing[id]...)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].From != result[j].From {
+			return result[i].From < result[j].From
+		}
+
+		return result[i].To < result[j].To
+	})
+
+	return result
+}
+
+// OutgoingEdges returns the edges starting at the access provider with the given id.
+func (g *AccessProviderGraph) OutgoingEdges(id string) []AccessProviderGraphEdge {
+	return g.outgoing[id]
+}
+
+// IncomingEdges returns the edges ending at the access provider with the given id.
+func (g *AccessProviderGraph) IncomingEdges(id string) []AccessProviderGraphEdge {
+	return g.incoming[id]
+}
+
+// Ancestors returns the ids of all access providers that have a path to the access provider with the given id, sorted by id.
+// The who items of the ancestors receive the access of the access provider.
+func (g *AccessProviderGraph) Ancestors(id string) []string {
+	return g.reachable(id, func(edge AccessProviderGraphEdge) string { return edge.From }, g.incoming)
+}
+
+// Descendants returns the ids of all access providers that can be reached from the access provider with the given id, sorted by id.
+// The who items of the access provider receive the access of its descendants.
+func (g *AccessProviderGraph) Descendants(id string) []string {
+	return g.reachable(id, func(edge AccessProviderGraphEdge) string { return edge.To }, g.outgoing)
+}
+
+func (g *AccessProviderGraph) reachable(id string, next func(edge AccessProviderGraphEdge) string, edges map[string][]AccessProviderGraphEdge) []string {
+	visited := map[string]struct{}{}
+	queue := []string{id}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		for _, edge := range edges[current] {
+			nextId := next(edge)
+			if _, found := visited[nextId]; found {
+				continue
+			}
+
+			visited[nextId] = struct{}{}
+			queue = append(queue, nextId)
+		}
+	}
+
+	delete(visited, id)
+
+	result := make([]string, 0, len(visited))
+	for nodeId := range visited {
+		result = append(result, nodeId)
+	}
+
+	sort.Strings(result)
+
+	return result
+}
+
+// Cycles returns the groups of access providers that inherit from each other in a cycle.
+// Each group is a strongly connected component with more than one access provider or an access provider that inherits from itself.
+func (g *AccessProviderGraph) Cycles() [][]string {
+	index := 0
+	indices := make(map[string]int)
+	lowLinks := make(map[string]int)
+	onStack := make(map[string]bool)
+
+	var stack []string
+	var cycles [][]string
+
+	var strongConnect func(id string)
+	strongConnect = func(id string) {
+		indices[id] = index
+		lowLinks[id] = index
+		index++
+
+		stack = append(stack, id)
+		onStack[id] = true
+
+		selfLoop := false
+
+		for _, edge := range g.outgoing[id] {
+			if edge.To == id {
+				selfLoop = true
+			}
+
+			if _, visited := indices[edge.To]; !visited {
+				strongConnect(edge.To)
+				lowLinks[id] = min(lowLinks[id], lowLinks[edge.To])
+			} else if onStack[edge.To] {
+				lowLinks[id] = min(lowLinks[id], indices[edge.To])
+			}
+		}
+
+		if lowLinks[id] != indices[id] {
+			return
+		}
+
+		var component []string
+
+		for {
+			last := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[last] = false
+
+			component = append(component, last)
+
+			if last == id {
+				break
+			}
+		}
+
+		if len(component) > 1 || selfLoop {
+			sort.Strings(component)
+			cycles = append(cycles, component)
+		}
+	}
+
+	for _, id := range g.sortedIds() {
+		if _, visited := indices[id]; !visited {
+			strongConnect(id)
+		}
+	}
+
+	sort.Slice(cycles, func(i, j int) bool {
+		return cycles[i][0] < cycles[j][0]
+	})
+
+	return cycles
+}
+
+// Orphans returns the ids of the access providers without inheritance edges and without who items, sorted by id.
+// Those access providers do not grant access to anyone.
+// Access providers with a dynamic who are never orphans, as their who rule can match users and groups.
+func (g *AccessProviderGraph) Orphans() []string {
+	var result []string
+
+	for _, id := range g.sortedIds() {
+		node := g.nodes[id]
+
+		if len(g.incoming[id]) == 0 && len(g.outgoing[id]) == 0 && node.WhoItemCount == 0 && node.WhoType != types.WhoAndWhatTypeDynamic {
+			result = append(result, id)
+		}
+	}
+
+	return result
+}
+
+// WriteDOT writes the graph in the Graphviz DOT format to w.
+// Who inheritance edges are drawn solid, what inheritance edges are drawn dashed.
+func (g *AccessProviderGraph) WriteDOT(w io.Writer) error {
+	_, err := fmt.Fprintln(w, "digraph AccessProviders {")
+	if err != nil {
+		return fmt.Errorf("write dot: %w", err)
+	}
+
+	for _, node := range g.Nodes() {
+		_, err = fmt.Fprintf(w, "\t%s [label=%s];\n", strconv.Quote(node.Id), strconv.Quote(node.Name))
+		if err != nil {
+			return fmt.Errorf("write dot: %w", err)
+		}
+	}
+
+	for _, edge := range g.Edges() {
+		style := "solid"
+		if edge.Type == AccessProviderGraphEdgeTypeWhatInheritance {
+			style = "dashed"
+		}
+
+		_, err = fmt.Fprintf(w, "\t%s -> %s [label=%s, style=%s];\n", strconv.Quote(edge.From), strconv.Quote(edge.To), strconv.Quote(string(edge.Type)), style)
+		if err != nil {
+			return fmt.Errorf("write dot: %w", err)
+		}
+	}
+
+	_, err = fmt.Fprintln(w, "}")
+	if err != nil {
+		return fmt.Errorf("write dot: %w", err)
+	}
+
+	return nil
+}
+
+type accessProviderGraphJSON struct {
+	Nodes []AccessProviderGraphNode `json:"nodes"`
+	Edges []AccessProviderGraphEdge `json:"edges"`
+}
+
+// MarshalJSON returns the nodes and edges of the graph as JSON.
+func (g *AccessProviderGraph) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(accessProviderGraphJSON{Nodes: g.Nodes(), Edges: g.Edges()})
+	if err != nil {
+		return nil, fmt.Errorf("marshal access provider graph: %w", err)
+	}
+
+	return data, nil
+}
+
+// UnmarshalJSON loads a graph that was exported with MarshalJSON.
+func (g *AccessProviderGraph) UnmarshalJSON(data []byte) error {
+	var graph accessProviderGraphJSON
+
+	err := json.Unmarshal(data, &graph)
+	if err != nil {
+		return fmt.Errorf("unmarshal access provider graph: %w", err)
+	}
+
+	*g = *newAccessProviderGraph()
+
+	for _, node := range graph.Nodes {
+		g.addNode(node)
+	}
+
+	for _, edge := range graph.Edges {
+		g.addEdge(edge)
+	}
+
+	return nil
+}
+
+func (g *AccessProviderGraph) sortedIds() []string {
+	ids := make([]string, 0, len(g.nodes))
+	for id := range g.nodes {
+		ids = append(ids, id)
+	}
+
+	sort.Strings(ids)
+
+	return ids
+}
+
+type AccessProviderGraphOptions struct {
+	concurrency int
+}
+
+// WithAccessProviderGraphConcurrency sets the maximum number of concurrent requests used to load the who and what lists in the BuildAccessProviderGraph call.
+func WithAccessProviderGraphConcurrency(concurrency int) func(options *AccessProviderGraphOptions) {
+	return func(options *AccessProviderGraphOptions) {
+		options.concurrency = concurrency
+	}
+}
+
+// BuildAccessProviderGraph loads the access providers that match the filter and returns the inheritance graph between them.
+// Edges to access providers that do not match the filter are not included.
+// If filter is nil, all access providers are loaded.
+func (a *AccessProviderClient) BuildAccessProviderGraph(ctx context.Context, filter *types.AccessProviderFilterInput, ops ...func(options *AccessProviderGraphOptions)) (*AccessProviderGraph, error) {
+	options := AccessProviderGraphOptions{
+		concurrency: internal.MaxConcurrentRequests,
+	}
+
+	for _, op := range ops {
+		op(&options)
+	}
+
+	loader := newAccessProviderLoader(a.client)
+
+	accessProviders, err := loader.listAccessProviders(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(accessProviders))
+	for i := range accessProviders {
+		ids = append(ids, accessProviders[i].Id)
+	}
+
+	err = forEachConcurrent(ctx, ids, options.concurrency, func(ctx context.Context, id string) error {
+		if _, loadErr := loader.whoList(ctx, id); loadErr != nil {
+			return loadErr
+		}
+
+		_, loadErr := loader.whatAccessProviderList(ctx, id)
+
+		return loadErr
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	graph := newAccessProviderGraph()
+
+	for i := range accessProviders {
+		whoItems, _ := loader.whoList(ctx, accessProviders[i].Id)
+
+		whoItemCount := 0
+
+		for j := range whoItems {
+			if _, isAccessProvider := whoItems[j].Item.(*types.AccessProviderWhoListItemItemAccessProvider); !isAccessProvider {
+				whoItemCount++
+			}
+		}
+
+		graph.addNode(AccessProviderGraphNode{
+			Id:           accessProviders[i].Id,
+			Name:         accessProviders[i].Name,
+			Action:       accessProviders[i].Action,
+			State:        accessProviders[i].State,
+			WhoType:      accessProviders[i].WhoType,
+			WhoItemCount: whoItemCount,
+		})
+	}
+
+	for i := range accessProviders {
+		apId := accessProviders[i].Id
+
+		whoItems, _ := loader.whoList(ctx, apId)
+
+		for j := range whoItems {
+			if item, ok := whoItems[j].Item.(*types.AccessProviderWhoListItemItemAccessProvider); ok && graph.nodes[item.Id] != nil {
+				graph.addEdge(AccessProviderGraphEdge{From: item.Id, To: apId, Type: AccessProviderGraphEdgeTypeWhoInheritance})
+			}
+		}
+
+		whatAccessProviders, _ := loader.whatAccessProviderList(ctx, apId)
+
+		for j := range whatAccessProviders {
+			if whatAccessProviders[j].AccessProvider != nil && graph.nodes[whatAccessProviders[j].AccessProvider.Id] != nil {
+				graph.addEdge(AccessProviderGraphEdge{From: apId, To: whatAccessProviders[j].AccessProvider.Id, Type: AccessProviderGraphEdgeTypeWhatInheritance})
+			}
+		}
+	}
+
+	return graph, nil
+}
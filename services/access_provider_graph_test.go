@@ -0,0 +1,179 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/raito-io/sdk-go/types"
+)
+
+// newTestAccessProviderGraph returns a graph with a node per id and who inheritance edges given as from/to pairs.
+// Nodes starting with "w" have who items, nodes starting with "d" have a dynamic who.
+func newTestAccessProviderGraph(ids []string, edges [][2]string) *AccessProviderGraph {
+	graph := newAccessProviderGraph()
+
+	for _, id := range ids {
+		whoItemCount := 0
+		if id[0] == 'w' {
+			whoItemCount = 1
+		}
+
+		whoType := types.WhoAndWhatTypeStatic
+		if id[0] == 'd' {
+			whoType = types.WhoAndWhatTypeDynamic
+		}
+
+		graph.addNode(AccessProviderGraphNode{Id: id, Name: id, WhoType: whoType, WhoItemCount: whoItemCount})
+	}
+
+	for _, edge := range edges {
+		graph.addEdge(AccessProviderGraphEdge{From: edge[0], To: edge[1], Type: AccessProviderGraphEdgeTypeWhoInheritance})
+	}
+
+	return graph
+}
+
+func TestAccessProviderGraph(t *testing.T) {
+	tests := []struct {
+		name                string
+		ids                 []string
+		edges               [][2]string
+		expectedCycles      [][]string
+		expectedOrphans     []string
+		expectedAncestors   map[string][]string
+		expectedDescendants map[string][]string
+	}{
+		{
+			name:  "diamond",
+			ids:   []string{"a", "b", "c", "d"},
+			edges: [][2]string{{"a", "b"}, {"a", "c"}, {"b", "d"}, {"c", "d"}},
+			expectedAncestors: map[string][]string{
+				"a": {},
+				"d": {"a", "b", "c"},
+			},
+			expectedDescendants: map[string][]string{
+				"a": {"b", "c", "d"},
+				"b": {"d"},
+				"d": {},
+			},
+		},
+		{
+			name:           "cycle with a tail",
+			ids:            []string{"a", "b", "c", "d"},
+			edges:          [][2]string{{"a", "b"}, {"b", "c"}, {"c", "a"}, {"c", "d"}},
+			expectedCycles: [][]string{{"a", "b", "c"}},
+			expectedAncestors: map[string][]string{
+				"a": {"b", "c"},
+				"d": {"a", "b", "c"},
+			},
+			expectedDescendants: map[string][]string{
+				"b": {"a", "c", "d"},
+				"d": {},
+			},
+		},
+		{
+			name:           "self loop and separate cycles",
+			ids:            []string{"a", "b", "c", "d", "e"},
+			edges:          [][2]string{{"a", "a"}, {"b", "c"}, {"c", "b"}, {"d", "e"}, {"e", "d"}},
+			expectedCycles: [][]string{{"a"}, {"b", "c"}, {"d", "e"}},
+			expectedAncestors: map[string][]string{
+				"a": {},
+				"b": {"c"},
+			},
+			expectedDescendants: map[string][]string{
+				"a": {},
+				"e": {"d"},
+			},
+		},
+		{
+			name:            "disconnected nodes",
+			ids:             []string{"a", "b", "d1", "o1", "o2", "w1"},
+			edges:           [][2]string{{"a", "b"}},
+			expectedOrphans: []string{"o1", "o2"},
+			expectedAncestors: map[string][]string{
+				"b":  {"a"},
+				"o1": {},
+				"w1": {},
+			},
+			expectedDescendants: map[string][]string{
+				"a":       {"b"},
+				"o2":      {},
+				"missing": {},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			graph := newTestAccessProviderGraph(test.ids, test.edges)
+
+			assert.Equal(t, test.expectedCycles, graph.Cycles())
+			assert.Equal(t, test.expectedOrphans, graph.Orphans())
+
+			for id, expected := range test.expectedAncestors {
+				assert.Equal(t, expected, graph.Ancestors(id), "ancestors of %s", id)
+			}
+
+			for id, expected := range test.expectedDescendants {
+				assert.Equal(t, expected, graph.Descendants(id), "descendants of %s", id)
+			}
+		})
+	}
+}
+
+func TestAccessProviderGraph_JSON(t *testing.T) {
+	graph := newTestAccessProviderGraph([]string{"a", "b", "w1"}, [][2]string{{"a", "b"}, {"b", "a"}})
+	graph.addEdge(AccessProviderGraphEdge{From: "w1", To: "a", Type: AccessProviderGraphEdgeTypeWhatInheritance})
+
+	data, err := json.Marshal(graph)
+	require.NoError(t, err)
+
+	var result AccessProviderGraph
+
+	require.NoError(t, json.Unmarshal(data, &result))
+
+	assert.Equal(t, graph.Nodes(), result.Nodes())
+	assert.Equal(t, graph.Edges(), result.Edges())
+	assert.Equal(t, [][]string{{"a", "b"}}, result.Cycles())
+
+	err = json.Unmarshal([]byte(`{"nodes":"invalid"}`), &result)
+	require.ErrorContains(t, err, "unmarshal access provider graph")
+}
+
+func TestAccessProviderClient_BuildAccessProviderGraph(t *testing.T) {
+	client := newFakeGraphqlClient()
+
+	client.handle("ListAccessProviders", func(map[string]any) string {
+		return `{"accessProviders":` + pagedResult(
+			`{"__typename":"AccessProvider","id":"ap1","name":"AP 1","whoType":"Dynamic"}`,
+			`{"__typename":"AccessProvider","id":"ap2","name":"AP 2","whoType":"Static"}`,
+			`{"__typename":"AccessProvider","id":"ap3","name":"AP 3","whoType":"Static"}`,
+			`{"__typename":"AccessProvider","id":"ap4","name":"AP 4","whoType":"Static"}`,
+		) + `}`
+	})
+	client.handle("GetAccessProviderWhoList", func(variables map[string]any) string {
+		switch variables["id"] {
+		case "ap3":
+			return accessProviderResult("whoList", pagedResult(`{"__typename":"AccessWhoItem","type":"WhoGrant","item":{"__typename":"User","id":"u1"}}`))
+		case "ap4":
+			return accessProviderResult("whoList", pagedResult(`{"__typename":"AccessWhoItem","type":"WhoGrant","item":{"__typename":"AccessProvider","id":"ap3"}}`))
+		default:
+			return accessProviderResult("whoList", pagedResult())
+		}
+	})
+	client.handle("GetAccessProviderWhatAccessProviders", func(map[string]any) string {
+		return accessProviderResult("whatAccessProviders", pagedResult())
+	})
+
+	apClient := NewAccessProviderClient(client)
+
+	graph, err := apClient.BuildAccessProviderGraph(context.Background(), nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"ap2"}, graph.Orphans())
+	assert.Equal(t, []AccessProviderGraphEdge{{From: "ap3", To: "ap4", Type: AccessProviderGraphEdgeTypeWhoInheritance}}, graph.Edges())
+}
@@ -0,0 +1,57 @@
+package services
+
+import (
+	"context"
+
+	"github.com/Khan/genqlient/graphql"
+
+	"github.com/raito-io/sdk-go/internal"
+	"github.com/raito-io/sdk-go/types"
+)
+
+// accessProviderLoader loads and caches access providers and their who and what lists.
+// It is used by the analysis APIs that traverse the access providers.
+type accessProviderLoader struct {
+	accessProviderClient AccessProviderClient
+
+	whoLists            loadCache[[]types.AccessProviderWhoListItem]
+	whatDataObjects     loadCache[[]types.AccessProviderWhatListItem]
+	whatAccessProviders loadCache[[]types.AccessWhatAccessProviderItem]
+}
+
+func newAccessProviderLoader(client graphql.Client) *accessProviderLoader {
+	return &accessProviderLoader{
+		accessProviderClient: NewAccessProviderClient(client),
+	}
+}
+
+func (l *accessProviderLoader) listAccessProviders(ctx context.Context, filter *types.AccessProviderFilterInput) ([]types.AccessProvider, error) {
+	return l.accessProviderClient.listAccessProviders(ctx, filter)
+}
+
+func (l *accessProviderLoader) whoList(ctx context.Context, id string) ([]types.AccessProviderWhoListItem, error) {
+	return l.whoLists.get(id, func() ([]types.AccessProviderWhoListItem, error) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		return internal.CollectListItems(l.accessProviderClient.GetAccessProviderWhoList(ctx, id))
+	})
+}
+
+func (l *accessProviderLoader) whatDataObjectList(ctx context.Context, id string) ([]types.AccessProviderWhatListItem, error) {
+	return l.whatDataObjects.get(id, func() ([]types.AccessProviderWhatListItem, error) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		return internal.CollectListItems(l.accessProviderClient.GetAccessProviderWhatDataObjectList(ctx, id))
+	})
+}
+
+func (l *accessProviderLoader) whatAccessProviderList(ctx context.Context, id string) ([]types.AccessWhatAccessProviderItem, error) {
+	return l.whatAccessProviders.get(id, func() ([]types.AccessWhatAccessProviderItem, error) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		return internal.CollectListItems(l.accessProviderClient.GetAccessProviderWhatAccessProviderList(ctx, id))
+	})
+}
@@ -0,0 +1,732 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/aws/smithy-go/ptr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/raito-io/sdk-go/internal"
+	"github.com/raito-io/sdk-go/internal/schema"
+	"github.com/raito-io/sdk-go/types"
+	"github.com/raito-io/sdk-go/types/models"
+)
+
+func TestMergeWhoItems(t *testing.T) {
+	grant := types.AccessWhoItemTypeWhogrant
+	promise := types.AccessWhoItemTypeWhopromise
+
+	tests := []struct {
+		name     string
+		current  []types.WhoItemInput
+		added    []types.WhoItemInput
+		expected []types.WhoItemInput
+	}{
+		{
+			name:     "add to empty",
+			added:    []types.WhoItemInput{{User: ptr.String("u1")}},
+			expected: []types.WhoItemInput{{User: ptr.String("u1")}},
+		},
+		{
+			name:     "keep current items",
+			current:  []types.WhoItemInput{{User: ptr.String("u1")}, {Recipient: ptr.String("r1")}},
+			added:    []types.WhoItemInput{{Group: ptr.String("g1")}},
+			expected: []types.WhoItemInput{{User: ptr.String("u1")}, {Recipient: ptr.String("r1")}, {Group: ptr.String("g1")}},
+		},
+		{
+			name:     "replace item of the same principal",
+			current:  []types.WhoItemInput{{User: ptr.String("u1"), Type: &promise}, {User: ptr.String("u2")}},
+			added:    []types.WhoItemInput{{User: ptr.String("u1"), Type: &grant}},
+			expected: []types.WhoItemInput{{User: ptr.String("u2")}, {User: ptr.String("u1"), Type: &grant}},
+		},
+		{
+			name:     "principals of different types do not collide",
+			current:  []types.WhoItemInput{{User: ptr.String("x")}},
+			added:    []types.WhoItemInput{{Group: ptr.String("x")}, {AccessProvider: ptr.String("x")}, {DataSource: ptr.String("x")}},
+			expected: []types.WhoItemInput{{User: ptr.String("x")}, {Group: ptr.String("x")}, {AccessProvider: ptr.String("x")}, {DataSource: ptr.String("x")}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, mergeWhoItems(test.current, test.added))
+		})
+	}
+}
+
+func TestWhatDataObjectInputs(t *testing.T) {
+	whatItem := func(id string, permissions []*string, globalPermissions []*string) types.AccessProviderWhatListItem {
+		return types.AccessProviderWhatListItem{
+			DataObject:        &schema.AccessProviderWhatListItemDataObject{DataObject: schema.DataObject{Id: id}},
+			Permissions:       permissions,
+			GlobalPermissions: globalPermissions,
+		}
+	}
+
+	read := []*string{ptr.String("SELECT")}
+	readReversed := []*string{ptr.String("USAGE"), ptr.String("SELECT")}
+	readSorted := []*string{ptr.String("SELECT"), ptr.String("USAGE")}
+	globalRead := []*string{ptr.String("READ")}
+
+	tests := []struct {
+		name     string
+		items    []types.AccessProviderWhatListItem
+		expected []types.AccessProviderWhatInputDO
+	}{
+		{
+			name:     "no items",
+			expected: []types.AccessProviderWhatInputDO{},
+		},
+		{
+			name:  "group items with the same permissions",
+			items: []types.AccessProviderWhatListItem{whatItem("do1", read, nil), whatItem("do2", read, nil)},
+			expected: []types.AccessProviderWhatInputDO{
+				{Permissions: read, DataObjects: []*string{ptr.String("do1"), ptr.String("do2")}},
+			},
+		},
+		{
+			name:  "permission order does not matter",
+			items: []types.AccessProviderWhatListItem{whatItem("do1", readReversed, nil), whatItem("do2", readSorted, nil)},
+			expected: []types.AccessProviderWhatInputDO{
+				{Permissions: readReversed, DataObjects: []*string{ptr.String("do1"), ptr.String("do2")}},
+			},
+		},
+		{
+			name:  "split on permissions and global permissions",
+			items: []types.AccessProviderWhatListItem{whatItem("do1", read, nil), whatItem("do2", read, globalRead), whatItem("do3", nil, globalRead)},
+			expected: []types.AccessProviderWhatInputDO{
+				{Permissions: read, DataObjects: []*string{ptr.String("do1")}},
+				{Permissions: read, GlobalPermissions: globalRead, DataObjects: []*string{ptr.String("do2")}},
+				{GlobalPermissions: globalRead, DataObjects: []*string{ptr.String("do3")}},
+			},
+		},
+		{
+			name:     "skip items without data object",
+			items:    []types.AccessProviderWhatListItem{{Permissions: read}, whatItem("do1", read, nil)},
+			expected: []types.AccessProviderWhatInputDO{{Permissions: read, DataObjects: []*string{ptr.String("do1")}}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, whatDataObjectInputs(test.items))
+		})
+	}
+}
+
+func TestRemoveWhatDataObjects(t *testing.T) {
+	read := []*string{ptr.String("SELECT")}
+	byName := []types.AccessProviderWhatDoByNameInput{{Fullname: "db.schema.table", Datasource: "ds1"}}
+
+	tests := []struct {
+		name     string
+		items    []types.AccessProviderWhatInputDO
+		toRemove []string
+		expected []types.AccessProviderWhatInputDO
+	}{
+		{
+			name:     "remove nothing",
+			items:    []types.AccessProviderWhatInputDO{{Permissions: read, DataObjects: []*string{ptr.String("do1")}}},
+			expected: []types.AccessProviderWhatInputDO{{Permissions: read, DataObjects: []*string{ptr.String("do1")}}},
+		},
+		{
+			name:     "remove a data object and keep the other fields",
+			items:    []types.AccessProviderWhatInputDO{{Permissions: read, DataObjects: []*string{ptr.String("do1"), ptr.String("do2")}, ExpiresAt: ptr.Time(time.Unix(1000, 0))}},
+			toRemove: []string{"do1"},
+			expected: []types.AccessProviderWhatInputDO{{Permissions: read, DataObjects: []*string{ptr.String("do2")}, ExpiresAt: ptr.Time(time.Unix(1000, 0))}},
+		},
+		{
+			name:     "drop items without data objects",
+			items:    []types.AccessProviderWhatInputDO{{Permissions: read, DataObjects: []*string{ptr.String("do1")}}, {DataObjects: []*string{ptr.String("do2")}}},
+			toRemove: []string{"do1"},
+			expected: []types.AccessProviderWhatInputDO{{DataObjects: []*string{ptr.String("do2")}}},
+		},
+		{
+			name:     "keep items with data objects by name",
+			items:    []types.AccessProviderWhatInputDO{{DataObjects: []*string{ptr.String("do1")}, DataObjectByName: byName}},
+			toRemove: []string{"do1"},
+			expected: []types.AccessProviderWhatInputDO{{DataObjects: []*string{}, DataObjectByName: byName}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			toRemove := make(map[string]struct{}, len(test.toRemove))
+			for _, id := range test.toRemove {
+				toRemove[id] = struct{}{}
+			}
+
+			assert.Equal(t, test.expected, removeWhatDataObjects(test.items, toRemove))
+		})
+	}
+}
+
+func TestAccessProviderClient_AccessProviderInput(t *testing.T) {
+	client := newFakeGraphqlClient()
+
+	client.handle("GetAccessProviderWhoList", func(map[string]any) string {
+		return accessProviderResult("whoList", pagedResult(
+			`{"__typename":"AccessWhoItem","type":"WhoGrant","expiresAt":"2030-01-01T00:00:00Z","expiresAfter":86400,"item":{"__typename":"User","id":"u1","name":"User 1"}}`,
+			`{"__typename":"AccessWhoItem","type":"WhoGrant","expiresAfter":3600,"item":{"__typename":"Group","id":"g1","name":"Group 1"}}`,
+			`{"__typename":"AccessWhoItem","type":"WhoPromise","promiseDuration":600,"item":{"__typename":"AccessProvider","id":"ap2","name":"AP 2"}}`,
+			`{"__typename":"AccessWhoItem","type":"WhoGrant","item":{"__typename":"DataSource","id":"ds2","name":"DS 2"}}`,
+		))
+	})
+
+	client.handle("GetAccessProviderWhatDataObjectList", func(map[string]any) string {
+		return accessProviderResult("whatDataObjects", pagedResult(
+			`{"__typename":"AccessWhatItem","dataObject":{"id":"do1"},"permissions":["SELECT"],"globalPermissions":["READ"]}`,
+			`{"__typename":"AccessWhatItem","dataObject":{"id":"do2"},"permissions":["SELECT"],"globalPermissions":["READ"]}`,
+		))
+	})
+
+	client.handle("GetAccessProviderWhatAccessProviders", func(map[string]any) string {
+		return accessProviderResult("whatAccessProviders", pagedResult(
+			`{"__typename":"AccessWhatAccessProviderItem","accessProvider":{"id":"ap3"},"expiresAt":"2031-01-01T00:00:00Z"}`,
+		))
+	})
+
+	ap := &types.AccessProvider{
+		Id:          "ap1",
+		Name:        "AP 1",
+		NamingHint:  ptr.String("ap_1"),
+		State:       models.AccessProviderStateActive,
+		Action:      models.AccessProviderActionGrant,
+		Category:    &schema.AccessProviderCategoryGrantCategory{GrantCategory: schema.GrantCategory{Id: "cat1"}},
+		Description: "Description",
+		PolicyRule:  ptr.String("rule"),
+		External:    true,
+		WhatType:    types.WhoAndWhatTypeStatic,
+		WhoType:     types.WhoAndWhatTypeStatic,
+		Locks: []schema.AccessProviderLocksAccessProviderLockData{
+			{AccessProviderLocks: schema.AccessProviderLocks{LockKey: types.AccessProviderLockWholock, Details: schema.AccessProviderLocksDetailsAccessProviderLockDetails{AccessProviderLockDetails: schema.AccessProviderLockDetails{Reason: ptr.String("managed")}}}},
+			{AccessProviderLocks: schema.AccessProviderLocks{LockKey: types.AccessProviderLockDeletelock}},
+		},
+		SyncData: []schema.AccessProviderSyncData{
+			{SyncData: schema.SyncData{DataSource: schema.SyncDataDataSource{DataSource: schema.DataSource{Id: "ds1"}}, AccessProviderType: &schema.SyncDataAccessProviderType{Type: ptr.String("role")}}},
+			{SyncData: schema.SyncData{DataSource: schema.SyncDataDataSource{DataSource: schema.DataSource{Id: "ds2"}}, MaskType: &schema.SyncDataMaskType{MaskType: schema.MaskType{ExternalId: "sha256"}}}},
+		},
+	}
+
+	apClient := NewAccessProviderClient(client)
+
+	input, err := apClient.accessProviderInput(context.Background(), ap)
+	require.NoError(t, err)
+
+	action := models.AccessProviderActionGrant
+	static := types.WhoAndWhatTypeStatic
+	grant := types.AccessWhoItemTypeWhogrant
+	promise := types.AccessWhoItemTypeWhopromise
+
+	expected := &types.AccessProviderInput{
+		Name:        ptr.String("AP 1"),
+		NamingHint:  ptr.String("ap_1"),
+		Action:      &action,
+		Description: ptr.String("Description"),
+		Category:    ptr.String("cat1"),
+		WhoType:     &static,
+		WhoItems: []types.WhoItemInput{
+			{User: ptr.String("u1"), Type: &grant, ExpiresAt: ptr.Time(time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC))},
+			{Group: ptr.String("g1"), Type: &grant, ExpiresAfter: ptr.Int64(3600)},
+			{AccessProvider: ptr.String("ap2"), Type: &promise, PromiseDuration: ptr.Int64(600)},
+			{DataSource: ptr.String("ds2"), Type: &grant},
+		},
+		WhatType:   &static,
+		PolicyRule: ptr.String("rule"),
+		DataSources: []types.AccessProviderDataSourceInput{
+			{DataSource: "ds1", Type: ptr.String("role")},
+			{DataSource: "ds2", Type: ptr.String("sha256")},
+		},
+		WhatDataObjects: []types.AccessProviderWhatInputDO{
+			{Permissions: []*string{ptr.String("SELECT")}, GlobalPermissions: []*string{ptr.String("READ")}, DataObjects: []*string{ptr.String("do1"), ptr.String("do2")}},
+		},
+		WhatAccessProviders: []types.AccessProviderWhatInputAP{
+			{AccessProvider: "ap3", ExpiresAt: ptr.Time(time.Date(2031, 1, 1, 0, 0, 0, 0, time.UTC))},
+		},
+		Locks: []types.AccessProviderLockDataInput{
+			{LockKey: types.AccessProviderLockWholock, Details: &types.AccessProviderLockDetailsInput{Reason: ptr.String("managed")}},
+			{LockKey: types.AccessProviderLockDeletelock},
+		},
+		External: ptr.Bool(true),
+	}
+
+	assert.Equal(t, expected, input)
+
+	// Fields that are not part of the state of a static grant. Every other input field must be filled in, so a new input field fails this test until it is round-tripped.
+	unset := map[string]struct{}{
+		"Source":                 {},
+		"WhoAbacRule":            {},
+		"WhatAbacRule":           {},
+		"FilterCriteria":         {},
+		"CommonWhatDataObjectId": {},
+	}
+
+	value := reflect.ValueOf(*input)
+	for i := range value.NumField() {
+		field := value.Type().Field(i)
+
+		if _, found := unset[field.Name]; found {
+			continue
+		}
+
+		assert.False(t, value.Field(i).IsZero(), "input field %s is not round-tripped", field.Name)
+	}
+}
+
+func TestAccessProviderClient_AccessProviderInput_Filter(t *testing.T) {
+	apClient := NewAccessProviderClient(newFakeGraphqlClient())
+
+	_, err := apClient.accessProviderInput(context.Background(), &types.AccessProvider{Id: "ap1", Action: models.AccessProviderActionFiltered})
+
+	var invalidInput *types.ErrInvalidInput
+	assert.ErrorAs(t, err, &invalidInput)
+}
+
+func TestAccessProviderClient_AccessProviderInput_Recipient(t *testing.T) {
+	client := newFakeGraphqlClient()
+	client.handle("GetAccessProviderWhoList", func(map[string]any) string {
+		return accessProviderResult("whoList", pagedResult(`{"__typename":"AccessWhoItem","type":"WhoGrant","item":{"__typename":"DataShareRecipient"}}`))
+	})
+
+	apClient := NewAccessProviderClient(client)
+
+	_, err := apClient.accessProviderInput(context.Background(), &types.AccessProvider{Id: "ap1", Action: models.AccessProviderActionGrant, WhoType: types.WhoAndWhatTypeStatic})
+
+	var invalidInput *types.ErrInvalidInput
+	assert.ErrorAs(t, err, &invalidInput)
+}
+
+// handlePatchAccessProvider registers the handlers to load and update a static access provider.
+// modifiedAt returns the modifiedAt of the access provider for the n-th GetAccessProvider call, starting at 1.
+func handlePatchAccessProvider(client *fakeGraphqlClient, whoType types.WhoAndWhatType, modifiedAt func(n int) time.Time) {
+	getCalls := 0
+
+	client.handle("GetAccessProvider", func(map[string]any) string {
+		getCalls++
+
+		return fmt.Sprintf(`{"accessProvider":{"__typename":"AccessProvider","id":"ap1","name":"AP 1","action":"Grant","whoType":%q,"whatType":"Static","modifiedAt":%q}}`,
+			whoType, modifiedAt(getCalls).Format(time.RFC3339))
+	})
+	client.handle("GetAccessProviderWhoList", func(map[string]any) string {
+		return accessProviderResult("whoList", pagedResult(`{"__typename":"AccessWhoItem","type":"WhoGrant","item":{"__typename":"User","id":"u1","name":"User 1"}}`))
+	})
+	client.handle("GetAccessProviderWhatDataObjectList", func(map[string]any) string {
+		return accessProviderResult("whatDataObjects", pagedResult())
+	})
+	client.handle("GetAccessProviderWhatAccessProviders", func(map[string]any) string {
+		return accessProviderResult("whatAccessProviders", pagedResult())
+	})
+	client.handle("UpdateAccessProvider", func(map[string]any) string {
+		return `{"updateAccessProvider":{"__typename":"AccessProvider","id":"ap1","name":"AP 1"}}`
+	})
+}
+
+func TestAccessProviderClient_UpdateAccessProvider_ExpectedVersion(t *testing.T) {
+	readAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name            string
+		modifiedAt      time.Time
+		expectedErr     bool
+		expectedUpdates int
+	}{
+		{name: "unmodified", modifiedAt: readAt, expectedUpdates: 1},
+		{name: "modified by another writer", modifiedAt: readAt.Add(time.Minute), expectedErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := newFakeGraphqlClient()
+			handlePatchAccessProvider(client, types.WhoAndWhatTypeStatic, func(int) time.Time { return test.modifiedAt })
+
+			apClient := NewAccessProviderClient(client)
+
+			result, err := apClient.UpdateAccessProvider(context.Background(), "ap1", types.AccessProviderInput{}, WithAccessProviderExpectedVersion(readAt))
+
+			if test.expectedErr {
+				var conflictErr *types.ErrConflict
+				require.ErrorAs(t, err, &conflictErr)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, "ap1", result.Id)
+			}
+
+			assert.Equal(t, 1, client.calls("GetAccessProvider"))
+			assert.Equal(t, test.expectedUpdates, client.calls("UpdateAccessProvider"))
+		})
+	}
+}
+
+func TestAccessProviderClient_PatchAccessProvider(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name            string
+		whoType         types.WhoAndWhatType
+		modifiedAt      func(n int) time.Time
+		expectedErr     any
+		expectedGets    int
+		expectedUpdates int
+	}{
+		{
+			name:    "conflict followed by success",
+			whoType: types.WhoAndWhatTypeStatic,
+			// The first load sees the old state, every later call the state of the concurrent update.
+			modifiedAt: func(n int) time.Time {
+				if n == 1 {
+					return start
+				}
+
+				return start.Add(time.Minute)
+			},
+			expectedGets:    4,
+			expectedUpdates: 1,
+		},
+		{
+			name:    "attempts run out",
+			whoType: types.WhoAndWhatTypeStatic,
+			// Every call sees a newer state, so every check conflicts.
+			modifiedAt: func(n int) time.Time {
+				return start.Add(time.Duration(n) * time.Minute)
+			},
+			expectedErr:  &types.ErrConflict{},
+			expectedGets: 2 * internal.MaxPatchAttempts,
+		},
+		{
+			name:    "patch error",
+			whoType: types.WhoAndWhatTypeDynamic,
+			modifiedAt: func(int) time.Time {
+				return start
+			},
+			expectedErr:  &types.ErrInvalidInput{},
+			expectedGets: 1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := newFakeGraphqlClient()
+			handlePatchAccessProvider(client, test.whoType, test.modifiedAt)
+
+			apClient := NewAccessProviderClient(client)
+
+			result, err := apClient.AddWhoItems(context.Background(), "ap1", []types.WhoItemInput{{Group: ptr.String("g1")}})
+
+			if test.expectedErr != nil {
+				require.Error(t, err)
+				assert.ErrorAs(t, err, reflect.New(reflect.TypeOf(test.expectedErr)).Interface())
+				assert.Nil(t, result)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, "ap1", result.Id)
+			}
+
+			assert.Equal(t, test.expectedGets, client.calls("GetAccessProvider"))
+			assert.Equal(t, test.expectedUpdates, client.calls("UpdateAccessProvider"))
+		})
+	}
+}
+
+func TestAccessProviderClient_ListExpiringWhoItems(t *testing.T) {
+	soon := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	past := time.Now().Add(-time.Hour).UTC().Truncate(time.Second)
+	later := time.Now().Add(48 * time.Hour).UTC().Truncate(time.Second)
+
+	client := newFakeGraphqlClient()
+
+	client.handle("ListAccessProviders", func(map[string]any) string {
+		return `{"accessProviders":` + pagedResult(
+			`{"__typename":"AccessProvider","id":"ap1","name":"AP 1"}`,
+			`{"__typename":"AccessProvider","id":"ap2","name":"AP 2"}`,
+			`{"__typename":"AccessProvider","id":"ap3","name":"AP 3"}`,
+		) + `}`
+	})
+	client.handle("GetAccessProviderWhoList", func(variables map[string]any) string {
+		switch variables["id"] {
+		case "ap1":
+			return accessProviderResult("whoList", pagedResult(
+				fmt.Sprintf(`{"__typename":"AccessWhoItem","type":"WhoGrant","expiresAt":%q,"item":{"__typename":"User","id":"u1"}}`, soon.Format(time.RFC3339)),
+				fmt.Sprintf(`{"__typename":"AccessWhoItem","type":"WhoGrant","expiresAt":%q,"item":{"__typename":"User","id":"u2"}}`, later.Format(time.RFC3339)),
+				`{"__typename":"AccessWhoItem","type":"WhoGrant","expiresAfter":60,"item":{"__typename":"User","id":"u3"}}`,
+				`{"__typename":"AccessWhoItem","type":"WhoGrant","item":{"__typename":"User","id":"u4"}}`,
+			))
+		case "ap3":
+			return accessProviderResult("whoList", pagedResult(
+				fmt.Sprintf(`{"__typename":"AccessWhoItem","type":"WhoGrant","expiresAt":%q,"item":{"__typename":"Group","id":"g1"}}`, past.Format(time.RFC3339)),
+			))
+		default:
+			return accessProviderResult("whoList", pagedResult())
+		}
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	apClient := NewAccessProviderClient(client)
+
+	items, err := internal.CollectListItems(apClient.ListExpiringWhoItems(ctx, 24*time.Hour))
+	require.NoError(t, err)
+
+	require.Len(t, items, 2)
+	assert.Equal(t, "ap1", items[0].AccessProviderId)
+	assert.Equal(t, "AP 1", items[0].AccessProviderName)
+	assert.Equal(t, soon, items[0].ExpiresAt.UTC())
+	assert.Equal(t, "ap3", items[1].AccessProviderId)
+	assert.Equal(t, past, items[1].ExpiresAt.UTC())
+	assert.Equal(t, 3, client.calls("GetAccessProviderWhoList"))
+}
+
+func TestAccessProviderClient_ListAccessProvidersByTag(t *testing.T) {
+	var filter any
+
+	client := newFakeGraphqlClient()
+	client.handle("ListAccessProviders", func(variables map[string]any) string {
+		filter = variables["filter"]
+
+		return `{"accessProviders":` + pagedResult(`{"__typename":"AccessProvider","id":"ap1","name":"AP 1"}`) + `}`
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	apClient := NewAccessProviderClient(client)
+
+	accessProviders, err := internal.CollectListItems(apClient.ListAccessProvidersByTag(ctx,
+		[]types.TagFilter{{Key: ptr.String("owner"), StringValue: ptr.String("finance")}},
+		WithAccessProviderListFilter(&types.AccessProviderFilterInput{
+			HasTags: []types.TagFilter{{Key: ptr.String("pii")}},
+		}),
+	))
+	require.NoError(t, err)
+
+	require.Len(t, accessProviders, 1)
+	assert.Equal(t, "ap1", accessProviders[0].Id)
+
+	require.IsType(t, map[string]any{}, filter)
+	assert.Equal(t, []any{
+		map[string]any{"key": "pii"},
+		map[string]any{"key": "owner", "stringValue": "finance"},
+	}, filter.(map[string]any)["hasTags"])
+}
+
+func TestAccessProviderClient_ListAccessProviderTags(t *testing.T) {
+	client := newFakeGraphqlClient()
+	client.handle("GetAccessProviderTags", func(variables map[string]any) string {
+		switch variables["id"] {
+		case "ap1":
+			return `{"accessProvider":{"__typename":"AccessProvider","tags":[{"key":"owner","stringValue":"finance"}]}}`
+		case "denied":
+			return `{"accessProvider":{"__typename":"PermissionDeniedError","message":"denied"}}`
+		default:
+			return `{"accessProvider":{"__typename":"NotFoundError","message":"not found"}}`
+		}
+	})
+
+	ctx := context.Background()
+	apClient := NewAccessProviderClient(client)
+
+	tags, err := apClient.ListAccessProviderTags(ctx, "ap1")
+	require.NoError(t, err)
+	assert.Equal(t, []types.Tag{{Key: "owner", StringValue: "finance"}}, tags)
+
+	var permissionDenied *types.ErrPermissionDenied
+
+	_, err = apClient.ListAccessProviderTags(ctx, "denied")
+	assert.ErrorAs(t, err, &permissionDenied)
+
+	var notFound *types.ErrNotFound
+
+	_, err = apClient.ListAccessProviderTags(ctx, "unknown")
+	assert.ErrorAs(t, err, &notFound)
+
+	assert.Zero(t, client.calls("GetAccessProvider"))
+}
+
+func TestAccessProviderClient_Owners(t *testing.T) {
+	client := newFakeGraphqlClient()
+	handleListRoles(client)
+
+	client.handle("ListRoleAssignmentsOnAccessProvider", func(variables map[string]any) string {
+		assert.Equal(t, map[string]any{"role": "owner"}, variables["filter"])
+
+		return `{"accessProvider":{"__typename":"AccessProvider","roleAssignments":` + pagedResult(
+			`{"__typename":"RoleAssignment","id":"ra1","to":{"__typename":"User","id":"u1"}}`,
+			`{"__typename":"RoleAssignment","id":"ra2","to":null}`,
+			`{"__typename":"RoleAssignment","id":"ra3","to":{"__typename":"Group","id":"g1"}}`,
+		) + `}}`
+	})
+
+	var updated map[string]any
+
+	client.handle("UpdateRoleAssigneesOnAccessProvider", func(variables map[string]any) string {
+		updated = variables
+
+		return `{"updateRoleAssigneesOnAccessProvider":{"__typename":"Role","id":"owner","name":"Owner"}}`
+	})
+
+	ctx := context.Background()
+	apClient := NewAccessProviderClient(client)
+
+	owners, err := apClient.GetAccessProviderOwners(ctx, "ap1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"u1", "g1"}, owners)
+
+	require.NoError(t, apClient.SetAccessProviderOwners(ctx, "ap1", "u2", "g2"))
+	assert.Equal(t, map[string]any{"apId": "ap1", "roleID": "owner", "assignees": []any{"u2", "g2"}}, updated)
+}
+
+func TestAccessProviderClient_Owners_NoOwnerRole(t *testing.T) {
+	client := newFakeGraphqlClient()
+	client.handle("ListRoles", func(map[string]any) string {
+		return `{"roles":` + pagedResult(`{"__typename":"Role","id":"viewer","name":"Viewer"}`) + `}`
+	})
+
+	apClient := NewAccessProviderClient(client)
+
+	var notFound *types.ErrNotFound
+
+	err := apClient.SetAccessProviderOwners(context.Background(), "ap1", "u1")
+	require.ErrorAs(t, err, &notFound)
+	assert.Zero(t, client.calls("UpdateRoleAssigneesOnAccessProvider"))
+}
+
+func TestAccessProviderClient_ListOwnedAccessProviders(t *testing.T) {
+	var filter any
+
+	client := newFakeGraphqlClient()
+	client.handle("ListAccessProviders", func(variables map[string]any) string {
+		filter = variables["filter"]
+
+		return `{"accessProviders":` + pagedResult(`{"__typename":"AccessProvider","id":"ap1","name":"AP 1"}`) + `}`
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	apClient := NewAccessProviderClient(client)
+
+	accessProviders, err := internal.CollectListItems(apClient.ListOwnedAccessProviders(ctx, "u1", WithAccessProviderListFilter(&types.AccessProviderFilterInput{Owners: []string{"u2"}})))
+	require.NoError(t, err)
+
+	require.Len(t, accessProviders, 1)
+
+	require.IsType(t, map[string]any{}, filter)
+	assert.Equal(t, []any{"u1"}, filter.(map[string]any)["owners"])
+}
+
+func TestAccessProviderClient_ListExpiringWhoItems_RelativeExpiry(t *testing.T) {
+	client := newFakeGraphqlClient()
+
+	client.handle("ListAccessProviders", func(map[string]any) string {
+		return `{"accessProviders":` + pagedResult(`{"__typename":"AccessProvider","id":"ap1","name":"AP 1"}`) + `}`
+	})
+	client.handle("GetAccessProviderWhoList", func(map[string]any) string {
+		return accessProviderResult("whoList", pagedResult(
+			`{"__typename":"AccessWhoItem","type":"WhoGrant","expiresAfter":60,"item":{"__typename":"User","id":"u1"}}`,
+			`{"__typename":"AccessWhoItem","type":"WhoGrant","item":{"__typename":"User","id":"u2"}}`,
+		))
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	apClient := NewAccessProviderClient(client)
+
+	items, err := internal.CollectListItems(apClient.ListExpiringWhoItems(ctx, time.Hour, WithExpiringWhoItemListRelativeExpiry()))
+	require.NoError(t, err)
+
+	require.Len(t, items, 1)
+	assert.True(t, items[0].ExpiresAt.IsZero())
+	assert.Equal(t, ptr.Int64(60), items[0].ExpiresAfter)
+}
+
+func TestAccessProviderClient_ExtendWhoItemExpiry(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	newExpiry := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	client := newFakeGraphqlClient()
+
+	// The access provider is modified between the first read and the update, so the patch is retried once.
+	handlePatchAccessProvider(client, types.WhoAndWhatTypeStatic, func(n int) time.Time {
+		if n <= 2 {
+			return start.Add(time.Duration(n) * time.Minute)
+		}
+
+		return start.Add(2 * time.Minute)
+	})
+	client.handle("GetAccessProviderWhoList", func(map[string]any) string {
+		return accessProviderResult("whoList", pagedResult(
+			`{"__typename":"AccessWhoItem","type":"WhoGrant","expiresAfter":60,"item":{"__typename":"User","id":"u1"}}`,
+			`{"__typename":"AccessWhoItem","type":"WhoGrant","item":{"__typename":"User","id":"u2"}}`,
+		))
+	})
+
+	var whoItems any
+
+	client.handle("UpdateAccessProvider", func(variables map[string]any) string {
+		whoItems = variables["ap"].(map[string]any)["whoItems"]
+
+		return `{"updateAccessProvider":{"__typename":"AccessProvider","id":"ap1","name":"AP 1"}}`
+	})
+
+	apClient := NewAccessProviderClient(client)
+
+	_, err := apClient.ExtendWhoItemExpiry(context.Background(), "ap1", types.WhoItemInput{User: ptr.String("u1")}, newExpiry)
+	require.NoError(t, err)
+
+	assert.Equal(t, 4, client.calls("GetAccessProvider"))
+	assert.Equal(t, 1, client.calls("UpdateAccessProvider"))
+
+	require.IsType(t, []any{}, whoItems)
+	require.Len(t, whoItems, 2)
+	assert.Equal(t, newExpiry.Format(time.RFC3339), whoItems.([]any)[0].(map[string]any)["expiresAt"])
+	assert.Nil(t, whoItems.([]any)[0].(map[string]any)["expiresAfter"])
+
+	var notFound *types.ErrNotFound
+
+	_, err = apClient.ExtendWhoItemExpiry(context.Background(), "ap1", types.WhoItemInput{User: ptr.String("u3")}, newExpiry)
+	require.ErrorAs(t, err, &notFound)
+	assert.Equal(t, 1, client.calls("UpdateAccessProvider"))
+}
+
+func TestAccessProviderClient_ConvertPromiseToGrant(t *testing.T) {
+	client := newFakeGraphqlClient()
+	handlePatchAccessProvider(client, types.WhoAndWhatTypeStatic, func(int) time.Time { return time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC) })
+	client.handle("GetAccessProviderWhoList", func(map[string]any) string {
+		return accessProviderResult("whoList", pagedResult(
+			`{"__typename":"AccessWhoItem","type":"WhoPromise","promiseDuration":3600,"item":{"__typename":"User","id":"u1"}}`,
+			`{"__typename":"AccessWhoItem","type":"WhoGrant","item":{"__typename":"User","id":"u2"}}`,
+		))
+	})
+
+	var whoItems any
+
+	client.handle("UpdateAccessProvider", func(variables map[string]any) string {
+		whoItems = variables["ap"].(map[string]any)["whoItems"]
+
+		return `{"updateAccessProvider":{"__typename":"AccessProvider","id":"ap1","name":"AP 1"}}`
+	})
+
+	apClient := NewAccessProviderClient(client)
+
+	_, err := apClient.ConvertPromiseToGrant(context.Background(), "ap1", types.WhoItemInput{User: ptr.String("u1")})
+	require.NoError(t, err)
+
+	require.IsType(t, []any{}, whoItems)
+
+	converted := whoItems.([]any)[0].(map[string]any)
+	assert.Equal(t, "WhoGrant", converted["type"])
+	assert.InDelta(t, 3600, converted["expiresAfter"], 0)
+	assert.Nil(t, converted["promiseDuration"])
+
+	var invalidInput *types.ErrInvalidInput
+
+	_, err = apClient.ConvertPromiseToGrant(context.Background(), "ap1", types.WhoItemInput{User: ptr.String("u2")})
+	require.ErrorAs(t, err, &invalidInput)
+	assert.Equal(t, 1, client.calls("UpdateAccessProvider"))
+}
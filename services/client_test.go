@@ -0,0 +1,85 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/Khan/genqlient/graphql"
+)
+
+// fakeGraphqlClient is a graphql.Client that answers requests with JSON returned by the handler registered for the operation.
+type fakeGraphqlClient struct {
+	mutex    sync.Mutex
+	handlers map[string]func(variables map[string]any) string
+	requests []string
+}
+
+func newFakeGraphqlClient() *fakeGraphqlClient {
+	return &fakeGraphqlClient{handlers: make(map[string]func(variables map[string]any) string)}
+}
+
+// handle registers the handler of an operation.
+func (c *fakeGraphqlClient) handle(opName string, handler func(variables map[string]any) string) {
+	c.handlers[opName] = handler
+}
+
+// calls returns the number of requests done for an operation.
+func (c *fakeGraphqlClient) calls(opName string) int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	count := 0
+
+	for _, request := range c.requests {
+		if request == opName {
+			count++
+		}
+	}
+
+	return count
+}
+
+func (c *fakeGraphqlClient) MakeRequest(_ context.Context, req *graphql.Request, resp *graphql.Response) error {
+	c.mutex.Lock()
+	c.requests = append(c.requests, req.OpName)
+	handler, found := c.handlers[req.OpName]
+	c.mutex.Unlock()
+
+	if !found {
+		return fmt.Errorf("unexpected operation %q", req.OpName)
+	}
+
+	variables := make(map[string]any)
+
+	if req.Variables != nil {
+		data, err := json.Marshal(req.Variables)
+		if err != nil {
+			return err
+		}
+
+		err = json.Unmarshal(data, &variables)
+		if err != nil {
+			return err
+		}
+	}
+
+	return json.Unmarshal([]byte(handler(variables)), resp.Data)
+}
+
+// pagedResult returns a single page PagedResult with the given nodes.
+func pagedResult(nodes ...string) string {
+	edges := make([]string, 0, len(nodes))
+	for i, node := range nodes {
+		edges = append(edges, fmt.Sprintf(`{"cursor":"%d","node":%s}`, i, node))
+	}
+
+	return fmt.Sprintf(`{"__typename":"PagedResult","pageInfo":{"hasNextPage":false},"edges":[%s]}`, strings.Join(edges, ","))
+}
+
+// accessProviderResult wraps the paged result of an access provider sub list in a response.
+func accessProviderResult(field string, page string) string {
+	return fmt.Sprintf(`{"accessProvider":{"__typename":"AccessProvider","%s":%s}}`, field, page)
+}
@@ -71,19 +71,97 @@ func (c *DataObjectClient) ListDataObjects(ctx context.Context, ops ...func(opti
 		return &output.DataObjects.PageInfo.PageInfo, output.DataObjects.Edges, nil
 	}
 
-	edgeFn := func(edge *types.DataObjectPageEdgesEdge) (*string, *schema.DataObject, error) {
-		cursor := edge.Cursor
+	return internal.PaginationExecutor(ctx, loadPageFn, dataObjectsEdgeFn)
+}
 
-		if edge.Node == nil {
-			return cursor, nil, nil
-		}
+// ListDataObjectsByTag returns a list of DataObjects that have all the given tags.
+// The tag filters are added to the filter set with WithDataObjectListFilter, if any.
+// A channel is returned that can be used to receive the list of DataObjectListItem
+// To close the channel ensure to cancel the context
+func (c *DataObjectClient) ListDataObjectsByTag(ctx context.Context, tags []types.TagFilter, ops ...func(options *DataObjectListOptions)) <-chan types.ListItem[types.DataObject] {
+	options := DataObjectListOptions{}
+	for _, op := range ops {
+		op(&options)
+	}
+
+	filter := types.DataObjectFilterInput{}
+	if options.filter != nil {
+		filter = *options.filter
+	}
 
-		listItem := (*edge.Node).(*types.DataObjectPageEdgesEdgeNodeDataObject)
+	filter.HasTags = append(append([]types.TagFilter{}, filter.HasTags...), tags...)
 
-		return cursor, &listItem.DataObject, nil
+	return c.ListDataObjects(ctx, WithDataObjectListOrder(options.order...), WithDataObjectListFilter(&filter))
+}
+
+// ListDataObjectTags returns the tags of the DataObject with the given id.
+func (c *DataObjectClient) ListDataObjectTags(ctx context.Context, id string) ([]types.Tag, error) {
+	result, err := schema.GetDataObjectTags(ctx, c.client, id)
+	if err != nil {
+		return nil, types.NewErrClient(err)
+	}
+
+	tags := make([]types.Tag, 0, len(result.DataObject.Tags))
+	for i := range result.DataObject.Tags {
+		tags = append(tags, result.DataObject.Tags[i].Tag)
+	}
+
+	return tags, nil
+}
+
+// GetDataObjectOwners returns the ids of the users and groups that own the DataObject with the given id.
+// Ownership is modelled as an assignment of the owner role (see RoleClient.GetOwnerRole) on the DataObject.
+func (c *DataObjectClient) GetDataObjectOwners(ctx context.Context, id string) ([]string, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	roleClient := NewRoleClient(c.client)
+
+	ownerRole, err := roleClient.GetOwnerRole(ctx)
+	if err != nil {
+		return nil, err
 	}
 
-	return internal.PaginationExecutor(ctx, loadPageFn, edgeFn)
+	return roleAssigneeIds(roleClient.ListRoleAssignmentsOnDataObject(ctx, id, WithRoleAssignmentListFilter(&types.RoleAssignmentFilterInput{Role: ptr.String(ownerRole.Id)})))
+}
+
+// SetDataObjectOwners sets the owners of the DataObject with the given id.
+// owners is a list of user and group ids. Existing owners that are not in the list are removed.
+func (c *DataObjectClient) SetDataObjectOwners(ctx context.Context, id string, owners ...string) error {
+	roleClient := NewRoleClient(c.client)
+
+	ownerRole, err := roleClient.GetOwnerRole(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = roleClient.UpdateRoleAssigneesOnDataObject(ctx, id, ownerRole.Id, owners...)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ListOwnedDataObjects returns a list of DataObjects owned by the given user.
+// The order of the list can be specified with WithDataObjectListOrder.
+// Additional filters can be specified with WithDataObjectListFilter.
+// A channel is returned that can be used to receive the list of DataObjectListItem
+// To close the channel ensure to cancel the context
+func (c *DataObjectClient) ListOwnedDataObjects(ctx context.Context, userId string, ops ...func(options *DataObjectListOptions)) <-chan types.ListItem[types.DataObject] {
+	options := DataObjectListOptions{}
+	for _, op := range ops {
+		op(&options)
+	}
+
+	filter := types.DataObjectFilterInput{}
+	if options.filter != nil {
+		filter = *options.filter
+	}
+
+	filter.Owners = []string{userId}
+
+	return c.ListDataObjects(ctx, WithDataObjectListOrder(options.order...), WithDataObjectListFilter(&filter))
 }
 
 type DataObjectByExternalIdOptions struct {
@@ -112,5 +190,18 @@ func (c *DataObjectClient) GetDataObjectIdByName(ctx context.Context, fullname s
 		return "", errors.New("unexpected number of results")
 	}
 
-	return (*result.DataObjects.Edges[0].Node).(*schema.DataObjectByExternalIdDataObjectsPagedResultEdgesEdgeNodeDataObject).Id, nil
+	_, id, err := internal.DecodeEdgeNode(nil, result.DataObjects.Edges[0].Node, func(node *schema.DataObjectByExternalIdDataObjectsPagedResultEdgesEdgeNodeDataObject) *string {
+		return &node.Id
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return *id, nil
+}
+
+func dataObjectsEdgeFn(edge *types.DataObjectPageEdgesEdge) (*string, *schema.DataObject, error) {
+	return internal.DecodeEdgeNode(edge.Cursor, edge.Node, func(node *types.DataObjectPageEdgesEdgeNodeDataObject) *schema.DataObject {
+		return &node.DataObject
+	})
 }
@@ -0,0 +1,463 @@
+package services
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/aws/smithy-go/ptr"
+
+	"github.com/raito-io/sdk-go/internal"
+	"github.com/raito-io/sdk-go/types"
+	"github.com/raito-io/sdk-go/types/models"
+)
+
+// DataObjectAccess describes the access of a single user or group to a data object.
+// AccessProviderId is the access provider that has the data object in its what.
+// Path starts with the principal, followed by the access provider that has the principal as who item and the inherited access providers up to the granting access provider.
+type DataObjectAccess struct {
+	PrincipalType      EffectiveAccessStepType `json:"principalType"`
+	PrincipalId        string                  `json:"principalId"`
+	PrincipalName      string                  `json:"principalName"`
+	AccessProviderId   string                  `json:"accessProviderId"`
+	AccessProviderName string                  `json:"accessProviderName"`
+	Path               []EffectiveAccessStep   `json:"path"`
+	Permissions        []string                `json:"permissions"`
+	GlobalPermissions  []string                `json:"globalPermissions"`
+	ExpiresAt          *time.Time              `json:"expiresAt,omitempty"`
+	// ExpiresAfter is the shortest relative expiry (expiresAfter) of the who items along the path.
+	ExpiresAfter *int64 `json:"expiresAfter,omitempty"`
+}
+
+type DataObjectAccessListOptions struct {
+	filter      *types.AccessProviderFilterInput
+	concurrency int
+	expandUsers []string
+}
+
+// WithDataObjectAccessListFilter sets the filter of the access providers that are taken into account in the ListAccessOnDataObject call.
+// By default, only active grant and purpose access providers are used.
+func WithDataObjectAccessListFilter(filter *types.AccessProviderFilterInput) func(options *DataObjectAccessListOptions) {
+	return func(options *DataObjectAccessListOptions) {
+		options.filter = filter
+	}
+}
+
+// WithDataObjectAccessListConcurrency sets the maximum number of concurrent requests used to load access providers in the ListAccessOnDataObject call.
+func WithDataObjectAccessListConcurrency(concurrency int) func(options *DataObjectAccessListOptions) {
+	return func(options *DataObjectAccessListOptions) {
+		options.concurrency = concurrency
+	}
+}
+
+// WithDataObjectAccessListGroupExpansion expands groups into the given users in the ListAccessOnDataObject call.
+// The group memberships, including ancestor groups, of each user are loaded, which requires two requests per user.
+// A member of a group with access is returned as a user with a path that starts with the user, followed by the group.
+//
+// Groups are only expanded into the given users: the API does not support listing the members of a group,
+// so members of a group with access that are not given here are not returned. Without this option, no group is expanded.
+func WithDataObjectAccessListGroupExpansion(userIds ...string) func(options *DataObjectAccessListOptions) {
+	return func(options *DataObjectAccessListOptions) {
+		options.expandUsers = append(options.expandUsers, userIds...)
+	}
+}
+
+type dataObjectAccessNode struct {
+	accessProviderId   string
+	accessProviderName string
+	grantingId         string
+	tail               []EffectiveAccessStep
+	expiresAt          *time.Time
+	expiresAfter       *int64
+	permissions        []string
+	globalPermissions  []string
+}
+
+type dataObjectAccessEdge struct {
+	cursor string
+	item   *DataObjectAccess
+}
+
+// ListAccessOnDataObject returns all users and groups that have access to the DataObject with the given id.
+// Access providers that have the data object in their what are followed back through who inheritance
+// (access providers used as who item) and what inheritance (access providers that include the access provider in their what).
+// Promise who items and who items or what access providers that expired are ignored as they do not grant access.
+// Groups are returned as a principal. Use WithDataObjectAccessListGroupExpansion to also return given members of the groups.
+// A channel is returned that can be used to receive the list of DataObjectAccess.
+// To close the channel ensure to cancel the context.
+func (c *DataObjectClient) ListAccessOnDataObject(ctx context.Context, doId string, ops ...func(options *DataObjectAccessListOptions)) <-chan types.ListItem[DataObjectAccess] {
+	return c.listAccessOnDataObject(ctx, func(context.Context) (string, error) { return doId, nil }, ops...)
+}
+
+// ListAccessOnDataObjectByName is the same as ListAccessOnDataObject, for the DataObject with the given full name in the given data source.
+func (c *DataObjectClient) ListAccessOnDataObjectByName(ctx context.Context, fullName string, dataSourceId string, ops ...func(options *DataObjectAccessListOptions)) <-chan types.ListItem[DataObjectAccess] {
+	return c.listAccessOnDataObject(ctx, func(ctx context.Context) (string, error) {
+		return c.GetDataObjectIdByName(ctx, fullName, dataSourceId)
+	}, ops...)
+}
+
+// listAccessOnDataObject implements ListAccessOnDataObject. The id of the data object is resolved when the first page is loaded.
+func (c *DataObjectClient) listAccessOnDataObject(ctx context.Context, dataObjectId func(ctx context.Context) (string, error), ops ...func(options *DataObjectAccessListOptions)) <-chan types.ListItem[DataObjectAccess] {
+	options := DataObjectAccessListOptions{
+		filter: &types.AccessProviderFilterInput{
+			States:  []models.AccessProviderState{models.AccessProviderStateActive},
+			Actions: []models.AccessProviderAction{models.AccessProviderActionGrant, models.AccessProviderActionPurpose},
+		},
+		concurrency: internal.MaxConcurrentRequests,
+	}
+
+	for _, op := range ops {
+		op(&options)
+	}
+
+	loader := newAccessProviderLoader(c.client)
+
+	var nodes []*dataObjectAccessNode
+
+	var groupMembers map[string][]EffectiveAccessStep
+
+	now := time.Now()
+
+	loadPageFn := func(ctx context.Context, cursor *string) (*types.PageInfo, []dataObjectAccessEdge, error) {
+		idx := 0
+
+		if cursor == nil {
+			doId, err := dataObjectId(ctx)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			nodes, err = c.dataObjectAccessNodes(ctx, loader, doId, now, &options)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			groupMembers, err = c.groupMembers(ctx, options.expandUsers, options.concurrency)
+			if err != nil {
+				return nil, nil, err
+			}
+		} else {
+			var err error
+
+			idx, err = strconv.Atoi(*cursor)
+			if err != nil {
+				return nil, nil, types.NewErrInvalidInput("invalid cursor " + *cursor)
+			}
+		}
+
+		if idx >= len(nodes) {
+			return &types.PageInfo{HasNextPage: ptr.Bool(false)}, nil, nil
+		}
+
+		edges, err := dataObjectAccessEdges(ctx, loader, nodes[idx], groupMembers, now, strconv.Itoa(idx+1))
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return &types.PageInfo{HasNextPage: ptr.Bool(idx+1 < len(nodes))}, edges, nil
+	}
+
+	edgeFn := func(edge *dataObjectAccessEdge) (*string, *DataObjectAccess, error) {
+		return &edge.cursor, edge.item, nil
+	}
+
+	return internal.PaginationExecutor(ctx, loadPageFn, edgeFn)
+}
+
+// dataObjectAccessNodes returns all access providers that give access to the data object, directly or through inheritance.
+// Who items and what access providers that expired before now are not followed.
+func (c *DataObjectClient) dataObjectAccessNodes(ctx context.Context, loader *accessProviderLoader, doId string, now time.Time, options *DataObjectAccessListOptions) ([]*dataObjectAccessNode, error) {
+	directFilter := types.AccessProviderFilterInput{}
+	if options.filter != nil {
+		directFilter = *options.filter
+	}
+
+	directFilter.DataObjectInWhat = ptr.String(doId)
+
+	granting, err := loader.listAccessProviders(ctx, &directFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	accessProviders, err := loader.listAccessProviders(ctx, options.filter)
+	if err != nil {
+		return nil, err
+	}
+
+	accessProviderNames := make(map[string]string, len(accessProviders))
+	accessProviderIds := make([]string, 0, len(accessProviders))
+
+	for i := range accessProviders {
+		accessProviderNames[accessProviders[i].Id] = accessProviders[i].Name
+		accessProviderIds = append(accessProviderIds, accessProviders[i].Id)
+	}
+
+	err = forEachConcurrent(ctx, accessProviderIds, options.concurrency, func(ctx context.Context, id string) error {
+		_, loadErr := loader.whatAccessProviderList(ctx, id)
+
+		return loadErr
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// includedIn maps an access provider to the access providers that have it in their what.
+	includedIn := make(map[string][]effectiveAccessEdge)
+
+	for _, apId := range accessProviderIds {
+		whatAccessProviders, _ := loader.whatAccessProviderList(ctx, apId)
+
+		for i := range whatAccessProviders {
+			if whatAccessProviders[i].AccessProvider == nil || isExpired(whatAccessProviders[i].ExpiresAt, now) {
+				continue
+			}
+
+			to := whatAccessProviders[i].AccessProvider.Id
+			includedIn[to] = append(includedIn[to], effectiveAccessEdge{to: apId, stepType: EffectiveAccessStepTypeWhatInheritance, expiresAt: whatAccessProviders[i].ExpiresAt})
+		}
+	}
+
+	// reached contains the visited access provider and granting access provider pairs.
+	reached := make(map[[2]string]struct{})
+	nodes := make([]*dataObjectAccessNode, 0, len(granting))
+
+	for i := range granting {
+		permissions, globalPermissions, permErr := c.dataObjectPermissions(ctx, loader, granting[i].Id, doId)
+		if permErr != nil {
+			return nil, permErr
+		}
+
+		reached[[2]string{granting[i].Id, granting[i].Id}] = struct{}{}
+		nodes = append(nodes, &dataObjectAccessNode{
+			accessProviderId:   granting[i].Id,
+			accessProviderName: granting[i].Name,
+			grantingId:         granting[i].Id,
+			permissions:        permissions,
+			globalPermissions:  globalPermissions,
+		})
+	}
+
+	for idx := 0; idx < len(nodes); idx++ {
+		node := nodes[idx]
+
+		whoItems, whoErr := loader.whoList(ctx, node.accessProviderId)
+		if whoErr != nil {
+			return nil, whoErr
+		}
+
+		var inheritedFrom []effectiveAccessEdge
+
+		for i := range whoItems {
+			if whoItems[i].Type == types.AccessWhoItemTypeWhopromise || isExpired(whoItems[i].ExpiresAt, now) {
+				continue
+			}
+
+			if item, ok := whoItems[i].Item.(*types.AccessProviderWhoListItemItemAccessProvider); ok {
+				inheritedFrom = append(inheritedFrom, effectiveAccessEdge{to: item.Id, stepType: EffectiveAccessStepTypeWhoInheritance, expiresAt: whoItems[i].ExpiresAt, expiresAfter: whoItems[i].ExpiresAfter})
+			}
+		}
+
+		inheritedFrom = append(inheritedFrom, includedIn[node.accessProviderId]...)
+
+		for _, edge := range inheritedFrom {
+			name, found := accessProviderNames[edge.to]
+			if !found {
+				continue
+			}
+
+			key := [2]string{edge.to, node.grantingId}
+			if _, found = reached[key]; found {
+				continue
+			}
+
+			reached[key] = struct{}{}
+
+			tail := make([]EffectiveAccessStep, 0, len(node.tail)+1)
+			tail = append(tail, EffectiveAccessStep{Type: edge.stepType, Id: node.accessProviderId, Name: node.accessProviderName})
+			tail = append(tail, node.tail...)
+
+			nodes = append(nodes, &dataObjectAccessNode{
+				accessProviderId:   edge.to,
+				accessProviderName: name,
+				grantingId:         node.grantingId,
+				tail:               tail,
+				expiresAt:          earliestTime(node.expiresAt, edge.expiresAt),
+				expiresAfter:       shortestDuration(node.expiresAfter, edge.expiresAfter),
+				permissions:        node.permissions,
+				globalPermissions:  node.globalPermissions,
+			})
+		}
+	}
+
+	return nodes, nil
+}
+
+// groupMembers returns the given users per group they are a member of, including ancestor groups.
+func (c *DataObjectClient) groupMembers(ctx context.Context, userIds []string, concurrency int) (map[string][]EffectiveAccessStep, error) {
+	if len(userIds) == 0 {
+		return nil, nil
+	}
+
+	userClient := NewUserClient(c.client)
+
+	var mutex sync.Mutex
+
+	memberships := make(map[string][]string, len(userIds))
+	users := make(map[string]EffectiveAccessStep, len(userIds))
+
+	err := forEachConcurrent(ctx, userIds, concurrency, func(ctx context.Context, userId string) error {
+		user, err := userClient.GetUser(ctx, userId)
+		if err != nil {
+			return err
+		}
+
+		groups, err := userClient.userGroups(ctx, userId)
+		if err != nil {
+			return err
+		}
+
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		users[userId] = EffectiveAccessStep{Type: EffectiveAccessStepTypeUser, Id: user.Id, Name: user.Name}
+
+		for groupId := range groups {
+			memberships[groupId] = append(memberships[groupId], userId)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string][]EffectiveAccessStep, len(memberships))
+
+	for groupId, members := range memberships {
+		sort.Strings(members)
+
+		for _, userId := range members {
+			if len(result[groupId]) > 0 && result[groupId][len(result[groupId])-1].Id == userId {
+				continue
+			}
+
+			result[groupId] = append(result[groupId], users[userId])
+		}
+	}
+
+	return result, nil
+}
+
+// dataObjectPermissions returns the permissions an access provider grants on a data object.
+func (c *DataObjectClient) dataObjectPermissions(ctx context.Context, loader *accessProviderLoader, apId string, doId string) ([]string, []string, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	whatItems, err := internal.CollectListItems(loader.accessProviderClient.GetAccessProviderWhatDataObjectList(ctx, apId, WithAccessProviderWhatListFilter(&types.AccessWhatFilterInput{TargetDataObject: ptr.String(doId)})))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var permissions, globalPermissions []string
+
+	for i := range whatItems {
+		permissions = appendUnique(permissions, derefStrings(whatItems[i].Permissions)...)
+		globalPermissions = appendUnique(globalPermissions, derefStrings(whatItems[i].GlobalPermissions)...)
+	}
+
+	return permissions, globalPermissions, nil
+}
+
+// dataObjectAccessEdges returns the users and groups in the who list of the access provider of the node.
+// Groups are followed by an edge for each of their members in groupMembers.
+// Promise who items and who items that expired before now are skipped.
+// At least one edge is returned, so the cursor always moves to the next node.
+func dataObjectAccessEdges(ctx context.Context, loader *accessProviderLoader, node *dataObjectAccessNode, groupMembers map[string][]EffectiveAccessStep, now time.Time, cursor string) ([]dataObjectAccessEdge, error) {
+	whoItems, err := loader.whoList(ctx, node.accessProviderId)
+	if err != nil {
+		return nil, err
+	}
+
+	grantingName := node.accessProviderName
+	if len(node.tail) > 0 {
+		grantingName = node.tail[len(node.tail)-1].Name
+	}
+
+	var edges []dataObjectAccessEdge
+
+	for i := range whoItems {
+		if whoItems[i].Type == types.AccessWhoItemTypeWhopromise || isExpired(whoItems[i].ExpiresAt, now) {
+			continue
+		}
+
+		var principal EffectiveAccessStep
+
+		switch item := whoItems[i].Item.(type) {
+		case *types.AccessProviderWhoListItemItemUser:
+			principal = EffectiveAccessStep{Type: EffectiveAccessStepTypeUser, Id: item.Id, Name: item.Name}
+		case *types.AccessProviderWhoListItemItemGroup:
+			principal = EffectiveAccessStep{Type: EffectiveAccessStepTypeGroup, Id: item.Id, Name: item.Name}
+		default:
+			continue
+		}
+
+		principals := [][]EffectiveAccessStep{{principal}}
+
+		if principal.Type == EffectiveAccessStepTypeGroup {
+			for _, member := range groupMembers[principal.Id] {
+				principals = append(principals, []EffectiveAccessStep{member, principal})
+			}
+		}
+
+		for _, steps := range principals {
+			path := make([]EffectiveAccessStep, 0, len(steps)+len(node.tail)+1)
+			path = append(path, steps...)
+			path = append(path, EffectiveAccessStep{Type: EffectiveAccessStepTypeAccessProvider, Id: node.accessProviderId, Name: node.accessProviderName})
+			path = append(path, node.tail...)
+
+			edges = append(edges, dataObjectAccessEdge{
+				cursor: cursor,
+				item: &DataObjectAccess{
+					PrincipalType:      steps[0].Type,
+					PrincipalId:        steps[0].Id,
+					PrincipalName:      steps[0].Name,
+					AccessProviderId:   node.grantingId,
+					AccessProviderName: grantingName,
+					Path:               path,
+					Permissions:        node.permissions,
+					GlobalPermissions:  node.globalPermissions,
+					ExpiresAt:          earliestTime(node.expiresAt, whoItems[i].ExpiresAt),
+					ExpiresAfter:       shortestDuration(node.expiresAfter, whoItems[i].ExpiresAfter),
+				},
+			})
+		}
+	}
+
+	if len(edges) == 0 {
+		edges = append(edges, dataObjectAccessEdge{cursor: cursor})
+	}
+
+	return edges, nil
+}
+
+func appendUnique(values []string, toAdd ...string) []string {
+	for _, value := range toAdd {
+		found := false
+
+		for _, existing := range values {
+			if existing == value {
+				found = true
+
+				break
+			}
+		}
+
+		if !found {
+			values = append(values, value)
+		}
+	}
+
+	return values
+}
@@ -0,0 +1,174 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/raito-io/sdk-go/internal"
+)
+
+func TestDataObjectClient_ListAccessOnDataObject_GroupExpansion(t *testing.T) {
+	client := newFakeGraphqlClient()
+
+	client.handle("ListAccessProviders", func(variables map[string]any) string {
+		filter, _ := variables["filter"].(map[string]any)
+		if _, found := filter["dataObjectInWhat"]; found {
+			return `{"accessProviders":` + pagedResult(`{"__typename":"AccessProvider","id":"ap1","name":"AP 1"}`) + `}`
+		}
+
+		return `{"accessProviders":` + pagedResult(`{"__typename":"AccessProvider","id":"ap1","name":"AP 1"}`, `{"__typename":"AccessProvider","id":"ap2","name":"AP 2"}`) + `}`
+	})
+	client.handle("GetAccessProviderWhatAccessProviders", func(map[string]any) string {
+		return accessProviderResult("whatAccessProviders", pagedResult())
+	})
+	client.handle("GetAccessProviderWhatDataObjectList", func(map[string]any) string {
+		return accessProviderResult("whatDataObjects", pagedResult(`{"__typename":"AccessWhatItem","dataObject":{"id":"do1"},"permissions":["SELECT"],"globalPermissions":["READ"]}`))
+	})
+	client.handle("GetAccessProviderWhoList", func(variables map[string]any) string {
+		if variables["id"] == "ap1" {
+			return accessProviderResult("whoList", pagedResult(
+				`{"__typename":"AccessWhoItem","type":"WhoGrant","item":{"__typename":"Group","id":"g1","name":"Group 1"}}`,
+			))
+		}
+
+		return accessProviderResult("whoList", pagedResult(
+			`{"__typename":"AccessWhoItem","type":"WhoGrant","item":{"__typename":"Group","id":"g2","name":"Group 2"}}`,
+		))
+	})
+	client.handle("GetUser", func(variables map[string]any) string {
+		return fmt.Sprintf(`{"user":{"__typename":"User","id":"%[1]s","name":"User %[1]s"}}`, variables["id"])
+	})
+	client.handle("ListGroups", func(variables map[string]any) string {
+		filter, _ := variables["filter"].(map[string]any)
+
+		switch filter["user"] {
+		case "u1":
+			return `{"groups":` + pagedResult(`{"__typename":"Group","id":"g1","name":"Group 1"}`, `{"__typename":"Group","id":"g2","name":"Group 2"}`) + `}`
+		case "u2":
+			return `{"groups":` + pagedResult(`{"__typename":"Group","id":"g1","name":"Group 1"}`) + `}`
+		default:
+			return `{"groups":` + pagedResult() + `}`
+		}
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	doClient := NewDataObjectClient(client)
+
+	items, err := internal.CollectListItems(doClient.ListAccessOnDataObject(ctx, "do1", WithDataObjectAccessListGroupExpansion("u2", "u1", "u3")))
+	require.NoError(t, err)
+
+	paths := make([][]string, 0, len(items))
+
+	for i := range items {
+		assert.Equal(t, "ap1", items[i].AccessProviderId)
+		assert.Equal(t, []string{"SELECT"}, items[i].Permissions)
+
+		var path []string
+		for _, step := range items[i].Path {
+			path = append(path, string(step.Type)+":"+step.Id)
+		}
+
+		paths = append(paths, path)
+	}
+
+	assert.Equal(t, [][]string{
+		{"Group:g1", "AccessProvider:ap1"},
+		{"User:u1", "Group:g1", "AccessProvider:ap1"},
+		{"User:u2", "Group:g1", "AccessProvider:ap1"},
+	}, paths)
+	assert.Equal(t, 3, client.calls("GetUser"))
+}
+
+func TestDataObjectClient_ListAccessOnDataObject_Expiry(t *testing.T) {
+	past := time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+	future := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+
+	client := newFakeGraphqlClient()
+
+	client.handle("DataObjectByExternalId", func(variables map[string]any) string {
+		assert.Equal(t, "db.schema.table", variables["fullname"])
+		assert.Equal(t, "ds1", variables["dataSourceId"])
+
+		return `{"dataObjects":{"edges":[{"node":{"__typename":"DataObject","id":"do1"}}]}}`
+	})
+	client.handle("ListAccessProviders", func(variables map[string]any) string {
+		filter, _ := variables["filter"].(map[string]any)
+		if filter["dataObjectInWhat"] == "do1" {
+			return `{"accessProviders":` + pagedResult(`{"__typename":"AccessProvider","id":"ap1","name":"AP 1"}`) + `}`
+		}
+
+		return `{"accessProviders":` + pagedResult(
+			`{"__typename":"AccessProvider","id":"ap1","name":"AP 1"}`,
+			`{"__typename":"AccessProvider","id":"ap2","name":"AP 2"}`,
+			`{"__typename":"AccessProvider","id":"ap3","name":"AP 3"}`,
+			`{"__typename":"AccessProvider","id":"ap4","name":"AP 4"}`,
+		) + `}`
+	})
+	client.handle("GetAccessProviderWhatAccessProviders", func(variables map[string]any) string {
+		switch variables["id"] {
+		case "ap3":
+			// ap3 includes ap1 in its what, but the link expired.
+			return accessProviderResult("whatAccessProviders", pagedResult(fmt.Sprintf(`{"__typename":"AccessWhatAccessProviderItem","expiresAt":%q,"accessProvider":{"id":"ap1"}}`, past)))
+		case "ap4":
+			return accessProviderResult("whatAccessProviders", pagedResult(`{"__typename":"AccessWhatAccessProviderItem","accessProvider":{"id":"ap1"}}`))
+		default:
+			return accessProviderResult("whatAccessProviders", pagedResult())
+		}
+	})
+	client.handle("GetAccessProviderWhatDataObjectList", func(map[string]any) string {
+		return accessProviderResult("whatDataObjects", pagedResult(`{"__typename":"AccessWhatItem","dataObject":{"id":"do1"},"permissions":["SELECT"]}`))
+	})
+	client.handle("GetAccessProviderWhoList", func(variables map[string]any) string {
+		switch variables["id"] {
+		case "ap1":
+			return accessProviderResult("whoList", pagedResult(
+				fmt.Sprintf(`{"__typename":"AccessWhoItem","type":"WhoGrant","expiresAt":%q,"item":{"__typename":"User","id":"u1","name":"User 1"}}`, past),
+				fmt.Sprintf(`{"__typename":"AccessWhoItem","type":"WhoGrant","expiresAt":%q,"item":{"__typename":"User","id":"u2","name":"User 2"}}`, future.Format(time.RFC3339)),
+				`{"__typename":"AccessWhoItem","type":"WhoGrant","expiresAfter":3600,"item":{"__typename":"Group","id":"g1","name":"Group 1"}}`,
+				fmt.Sprintf(`{"__typename":"AccessWhoItem","type":"WhoGrant","expiresAt":%q,"item":{"__typename":"AccessProvider","id":"ap2","name":"AP 2"}}`, past),
+			))
+		case "ap2", "ap3":
+			return accessProviderResult("whoList", pagedResult(`{"__typename":"AccessWhoItem","type":"WhoGrant","item":{"__typename":"User","id":"u3","name":"User 3"}}`))
+		default:
+			return accessProviderResult("whoList", pagedResult(`{"__typename":"AccessWhoItem","type":"WhoGrant","item":{"__typename":"User","id":"u4","name":"User 4"}}`))
+		}
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	doClient := NewDataObjectClient(client)
+
+	items, err := internal.CollectListItems(doClient.ListAccessOnDataObjectByName(ctx, "db.schema.table", "ds1"))
+	require.NoError(t, err)
+
+	principals := make([]string, 0, len(items))
+	for i := range items {
+		principals = append(principals, items[i].PrincipalId)
+	}
+
+	// u1 and the who inheritance from ap2 expired, the what inheritance from ap3 expired.
+	assert.Equal(t, []string{"u2", "g1", "u4"}, principals)
+
+	require.Len(t, items, 3)
+	assert.Equal(t, future, items[0].ExpiresAt.UTC())
+	assert.Equal(t, int64(3600), *items[1].ExpiresAfter)
+	assert.Equal(t, "ap1", items[2].AccessProviderId)
+	assert.Equal(t, []EffectiveAccessStepType{EffectiveAccessStepTypeUser, EffectiveAccessStepTypeAccessProvider, EffectiveAccessStepTypeWhatInheritance}, stepTypes(items[2].Path))
+}
+
+func stepTypes(path []EffectiveAccessStep) []EffectiveAccessStepType {
+	result := make([]EffectiveAccessStepType, 0, len(path))
+	for _, step := range path {
+		result = append(result, step.Type)
+	}
+
+	return result
+}
@@ -0,0 +1,115 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/smithy-go/ptr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/raito-io/sdk-go/internal"
+	"github.com/raito-io/sdk-go/types"
+)
+
+func TestDataObjectClient_ListDataObjectsByTag(t *testing.T) {
+	var filter any
+
+	client := newFakeGraphqlClient()
+	client.handle("ListDataObjects", func(variables map[string]any) string {
+		filter = variables["filter"]
+
+		return `{"dataObjects":` + pagedResult(`{"__typename":"DataObject","id":"do1","name":"table1"}`) + `}`
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	doClient := NewDataObjectClient(client)
+
+	dataObjects, err := internal.CollectListItems(doClient.ListDataObjectsByTag(ctx,
+		[]types.TagFilter{{Key: ptr.String("owner"), StringValue: ptr.String("finance")}},
+		WithDataObjectListFilter(&types.DataObjectFilterInput{
+			DataSources: []string{"ds1"},
+			HasTags:     []types.TagFilter{{Key: ptr.String("pii")}},
+		}),
+	))
+	require.NoError(t, err)
+
+	require.Len(t, dataObjects, 1)
+	assert.Equal(t, "do1", dataObjects[0].Id)
+
+	require.IsType(t, map[string]any{}, filter)
+	assert.Equal(t, []any{"ds1"}, filter.(map[string]any)["dataSources"])
+	assert.Equal(t, []any{
+		map[string]any{"key": "pii"},
+		map[string]any{"key": "owner", "stringValue": "finance"},
+	}, filter.(map[string]any)["hasTags"])
+}
+
+func TestDataObjectClient_ListDataObjectTags(t *testing.T) {
+	client := newFakeGraphqlClient()
+	client.handle("GetDataObjectTags", func(variables map[string]any) string {
+		assert.Equal(t, "do1", variables["dataObjectId"])
+
+		return `{"dataObject":{"tags":[{"key":"owner","stringValue":"finance"},{"key":"pii","stringValue":""}]}}`
+	})
+
+	doClient := NewDataObjectClient(client)
+
+	tags, err := doClient.ListDataObjectTags(context.Background(), "do1")
+	require.NoError(t, err)
+
+	assert.Equal(t, []types.Tag{{Key: "owner", StringValue: "finance"}, {Key: "pii"}}, tags)
+	assert.Zero(t, client.calls("GetDataObject"))
+}
+
+func TestDataObjectClient_Owners(t *testing.T) {
+	client := newFakeRoleAssignmentClient(map[RoleScope]map[string][]string{
+		DataObjectRoleScope("do1"): {"owner": {"u1", "g1"}},
+	})
+	handleListRoles(client)
+
+	var updated map[string]any
+
+	client.handle("UpdateRoleAssigneesOnDataObject", func(variables map[string]any) string {
+		updated = variables
+
+		return `{"updateRoleAssigneesOnDataObject":{"__typename":"Role","id":"owner","name":"Owner"}}`
+	})
+
+	ctx := context.Background()
+	doClient := NewDataObjectClient(client)
+
+	owners, err := doClient.GetDataObjectOwners(ctx, "do1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"u1", "g1"}, owners)
+
+	require.NoError(t, doClient.SetDataObjectOwners(ctx, "do1", "u2"))
+	assert.Equal(t, map[string]any{"doId": "do1", "roleID": "owner", "assignees": []any{"u2"}}, updated)
+}
+
+func TestDataObjectClient_ListOwnedDataObjects(t *testing.T) {
+	var filter any
+
+	client := newFakeGraphqlClient()
+	client.handle("ListDataObjects", func(variables map[string]any) string {
+		filter = variables["filter"]
+
+		return `{"dataObjects":` + pagedResult(`{"__typename":"DataObject","id":"do1","name":"table1"}`) + `}`
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	doClient := NewDataObjectClient(client)
+
+	dataObjects, err := internal.CollectListItems(doClient.ListOwnedDataObjects(ctx, "u1", WithDataObjectListFilter(&types.DataObjectFilterInput{Owners: []string{"u2"}, Types: []string{"table"}})))
+	require.NoError(t, err)
+
+	require.Len(t, dataObjects, 1)
+
+	require.IsType(t, map[string]any{}, filter)
+	assert.Equal(t, []any{"u1"}, filter.(map[string]any)["owners"])
+	assert.Equal(t, []any{"table"}, filter.(map[string]any)["types"])
+}
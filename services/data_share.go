@@ -0,0 +1,148 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/aws/smithy-go/ptr"
+
+	"github.com/raito-io/sdk-go/internal"
+	"github.com/raito-io/sdk-go/types"
+	"github.com/raito-io/sdk-go/types/models"
+)
+
+// DataShareClient manages data shares.
+// A data share is an access provider that has data share recipients in its who list.
+type DataShareClient struct {
+	client graphql.Client
+}
+
+func NewDataShareClient(client graphql.Client) DataShareClient {
+	return DataShareClient{
+		client: client,
+	}
+}
+
+// DataShare is an access provider that shares data objects with recipients.
+type DataShare struct {
+	AccessProvider types.AccessProvider `json:"accessProvider"`
+	RecipientCount int                  `json:"recipientCount"`
+}
+
+// ListDataShares returns all data shares on the DataSource with the given id.
+// Only grants on the DataSource are listed, as the filter of the access providers cannot select on recipients.
+// The who lists of these grants are loaded to count their recipients, except for grants with a dynamic who list.
+// Returns the list of DataShares if successful.
+// Otherwise, returns an error.
+func (c *DataShareClient) ListDataShares(ctx context.Context, dataSourceId string) ([]DataShare, error) {
+	loader := newAccessProviderLoader(c.client)
+
+	accessProviders, err := loader.listAccessProviders(ctx, &types.AccessProviderFilterInput{
+		DataSource: ptr.String(dataSourceId),
+		Actions:    []models.AccessProviderAction{models.AccessProviderActionGrant},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := make([]types.AccessProvider, 0, len(accessProviders))
+	ids := make([]string, 0, len(accessProviders))
+
+	for i := range accessProviders {
+		if accessProviders[i].WhoType == types.WhoAndWhatTypeDynamic {
+			continue
+		}
+
+		candidates = append(candidates, accessProviders[i])
+		ids = append(ids, accessProviders[i].Id)
+	}
+
+	err = forEachConcurrent(ctx, ids, internal.MaxConcurrentRequests, func(ctx context.Context, id string) error {
+		_, loadErr := loader.whoList(ctx, id)
+
+		return loadErr
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var shares []DataShare
+
+	for i := range candidates {
+		whoItems, _ := loader.whoList(ctx, candidates[i].Id)
+
+		recipients := 0
+
+		for j := range whoItems {
+			if _, ok := whoItems[j].Item.(*types.AccessProviderWhoListItemItemDataShareRecipient); ok {
+				recipients++
+			}
+		}
+
+		if recipients > 0 {
+			shares = append(shares, DataShare{AccessProvider: candidates[i], RecipientCount: recipients})
+		}
+	}
+
+	return shares, nil
+}
+
+// CreateDataShare creates an access provider that shares its what data objects with the given recipients.
+// All data sources of the access provider must support data sharing.
+// Returns the newly created AccessProvider if successful.
+// Otherwise, returns an error.
+func (c *DataShareClient) CreateDataShare(ctx context.Context, ap types.AccessProviderInput, recipients ...string) (*types.AccessProvider, error) {
+	if len(recipients) == 0 {
+		return nil, types.NewErrInvalidInput("a data share requires at least one recipient")
+	}
+
+	if len(ap.DataSources) == 0 {
+		return nil, types.NewErrInvalidInput("a data share requires a data source")
+	}
+
+	ids := make([]string, 0, len(ap.DataSources))
+	for i := range ap.DataSources {
+		ids = appendUnique(ids, ap.DataSources[i].DataSource)
+	}
+
+	unsupported, err := firstDataSourceNotSupporting(ctx, c.client, ids, types.DataSourceFeaturesDatasharing)
+	if err != nil {
+		return nil, err
+	}
+
+	if unsupported != "" {
+		return nil, types.NewErrInvalidInput(fmt.Sprintf("data source %q does not support %s", unsupported, types.DataSourceFeaturesDatasharing))
+	}
+
+	whoItems := make([]types.WhoItemInput, 0, len(ap.WhoItems)+len(recipients))
+	whoItems = append(whoItems, ap.WhoItems...)
+
+	for _, recipient := range recipients {
+		whoItems = append(whoItems, types.WhoItemInput{Recipient: ptr.String(recipient)})
+	}
+
+	ap.WhoItems = whoItems
+
+	apClient := NewAccessProviderClient(c.client)
+
+	return apClient.CreateAccessProvider(ctx, ap)
+}
+
+// ListSharedDataObjects returns the data objects shared by the data share with the given id.
+// A channel is returned that can be used to receive the list of AccessProviderWhatListItem.
+// To close the channel ensure to cancel the context.
+func (c *DataShareClient) ListSharedDataObjects(ctx context.Context, shareId string, ops ...func(*AccessProviderWhatListOptions)) <-chan types.ListItem[types.AccessProviderWhatListItem] {
+	apClient := NewAccessProviderClient(c.client)
+
+	return apClient.GetAccessProviderWhatDataObjectList(ctx, shareId, ops...)
+}
+
+// RevokeDataShare revokes the data share with the given id for all its recipients.
+// Returns nil if successful.
+// Otherwise, returns an error.
+func (c *DataShareClient) RevokeDataShare(ctx context.Context, shareId string) error {
+	apClient := NewAccessProviderClient(c.client)
+
+	return apClient.DeleteAccessProvider(ctx, shareId)
+}
@@ -0,0 +1,130 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/aws/smithy-go/ptr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/raito-io/sdk-go/types"
+)
+
+func TestDataShareClient_ListDataShares(t *testing.T) {
+	client := newFakeGraphqlClient()
+	client.handle("ListAccessProviders", func(variables map[string]any) string {
+		filter := variables["filter"].(map[string]any)
+		assert.Equal(t, "ds1", filter["dataSource"])
+		assert.Equal(t, []any{"Grant"}, filter["actions"])
+
+		return `{"accessProviders":` + pagedResult(
+			`{"__typename":"AccessProvider","id":"ap1","name":"Share","whoType":"Static"}`,
+			`{"__typename":"AccessProvider","id":"ap2","name":"Grant","whoType":"Static"}`,
+			`{"__typename":"AccessProvider","id":"ap3","name":"Rule","whoType":"Dynamic"}`,
+		) + `}`
+	})
+	client.handle("GetAccessProviderWhoList", func(variables map[string]any) string {
+		switch variables["id"] {
+		case "ap1":
+			return accessProviderResult("whoList", pagedResult(
+				`{"__typename":"AccessWhoItem","type":"WhoGrant","item":{"__typename":"DataShareRecipient"}}`,
+				`{"__typename":"AccessWhoItem","type":"WhoGrant","item":{"__typename":"User","id":"u1"}}`,
+				`{"__typename":"AccessWhoItem","type":"WhoGrant","item":{"__typename":"DataShareRecipient"}}`,
+			))
+		case "ap2":
+			return accessProviderResult("whoList", pagedResult(`{"__typename":"AccessWhoItem","type":"WhoGrant","item":{"__typename":"User","id":"u1"}}`))
+		default:
+			assert.Fail(t, "unexpected who list", variables["id"])
+
+			return accessProviderResult("whoList", pagedResult())
+		}
+	})
+
+	shareClient := NewDataShareClient(client)
+
+	shares, err := shareClient.ListDataShares(context.Background(), "ds1")
+	require.NoError(t, err)
+
+	require.Len(t, shares, 1)
+	assert.Equal(t, "ap1", shares[0].AccessProvider.Id)
+	assert.Equal(t, 2, shares[0].RecipientCount)
+	assert.Equal(t, 2, client.calls("GetAccessProviderWhoList"))
+}
+
+func TestDataShareClient_CreateDataShare(t *testing.T) {
+	dataSharing := func(variables map[string]any) string {
+		features := `[]`
+		if variables["id"] == "ds1" {
+			features = `["DataSharing"]`
+		}
+
+		return fmt.Sprintf(`{"dataSource":{"__typename":"DataSource","id":%q,"supportedFeatures":%s}}`, variables["id"], features)
+	}
+
+	tests := []struct {
+		name        string
+		input       types.AccessProviderInput
+		recipients  []string
+		expectedErr bool
+	}{
+		{
+			name:       "recipients are added to the who items",
+			input:      types.AccessProviderInput{Name: ptr.String("share"), DataSources: []types.AccessProviderDataSourceInput{{DataSource: "ds1"}}, WhoItems: []types.WhoItemInput{{User: ptr.String("u1")}}},
+			recipients: []string{"r1", "r2"},
+		},
+		{
+			name:        "no recipients",
+			input:       types.AccessProviderInput{DataSources: []types.AccessProviderDataSourceInput{{DataSource: "ds1"}}},
+			expectedErr: true,
+		},
+		{
+			name:        "no data source",
+			input:       types.AccessProviderInput{},
+			recipients:  []string{"r1"},
+			expectedErr: true,
+		},
+		{
+			name:        "data source without data sharing",
+			input:       types.AccessProviderInput{DataSources: []types.AccessProviderDataSourceInput{{DataSource: "ds2"}}},
+			recipients:  []string{"r1"},
+			expectedErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var whoItems []any
+
+			client := newFakeGraphqlClient()
+			client.handle("GetDataSourceCapabilities", dataSharing)
+			client.handle("CreateAccessProvider", func(variables map[string]any) string {
+				whoItems = variables["ap"].(map[string]any)["whoItems"].([]any)
+
+				return `{"createAccessProvider":{"__typename":"AccessProvider","id":"ap1"}}`
+			})
+
+			shareClient := NewDataShareClient(client)
+
+			ap, err := shareClient.CreateDataShare(context.Background(), test.input, test.recipients...)
+
+			if test.expectedErr {
+				var invalidInput *types.ErrInvalidInput
+				require.ErrorAs(t, err, &invalidInput)
+				assert.Equal(t, 0, client.calls("CreateAccessProvider"))
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, "ap1", ap.Id)
+			assert.Equal(t, []any{
+				map[string]any{"user": "u1"},
+				map[string]any{"recipient": "r1"},
+				map[string]any{"recipient": "r2"},
+			}, whoItems)
+			assert.Len(t, test.input.WhoItems, 1, "the input of the caller is not modified")
+		})
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/Khan/genqlient/graphql"
 	"github.com/aws/smithy-go/ptr"
@@ -44,10 +46,49 @@ func (c *DataSourceClient) CreateDataSource(ctx context.Context, ds types.DataSo
 	}
 }
 
+type UpdateDataSourceOptions struct {
+	unmodifiedSince *time.Time
+}
+
+// WithDataSourceIfUnmodifiedSince only updates the DataSource if it was not modified after t.
+// Pass the modifiedAt of the DataSource that was read to detect concurrent updates.
+// If the DataSource was modified after t, a types.ErrConflict is returned.
+// The check is best-effort: the client reads the current modifiedAt before sending the update,
+// so a concurrent write between that read and the update is not detected and can still be overwritten.
+func WithDataSourceIfUnmodifiedSince(t time.Time) func(options *UpdateDataSourceOptions) {
+	return func(options *UpdateDataSourceOptions) {
+		options.unmodifiedSince = &t
+	}
+}
+
+// WithDataSourceExpectedVersion only updates the DataSource if its current version is the expected version.
+// The version of a DataSource is its modifiedAt, so this is equivalent to WithDataSourceIfUnmodifiedSince(modifiedAt).
+func WithDataSourceExpectedVersion(modifiedAt time.Time) func(options *UpdateDataSourceOptions) {
+	return WithDataSourceIfUnmodifiedSince(modifiedAt)
+}
+
 // UpdateDataSource updates an existing DataSource.
 // Returns the updated DataSource if successful.
+// WithDataSourceIfUnmodifiedSince can be used to detect most concurrent modifications, but does not guarantee protection against them.
 // Otherwise, returns an error.
-func (c *DataSourceClient) UpdateDataSource(ctx context.Context, id string, ds types.DataSourceInput) (*types.DataSource, error) {
+func (c *DataSourceClient) UpdateDataSource(ctx context.Context, id string, ds types.DataSourceInput, ops ...func(options *UpdateDataSourceOptions)) (*types.DataSource, error) {
+	options := UpdateDataSourceOptions{}
+	for _, op := range ops {
+		op(&options)
+	}
+
+	if options.unmodifiedSince != nil {
+		current, err := c.GetDataSource(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		err = checkUnmodifiedSince("dataSource", id, current.ModifiedAt, options.unmodifiedSince)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	result, err := schema.UpdateDataSource(ctx, c.client, id, ds)
 	if err != nil {
 		return nil, types.NewErrClient(err)
@@ -65,10 +106,66 @@ func (c *DataSourceClient) UpdateDataSource(ctx context.Context, id string, ds t
 	}
 }
 
+type DeleteDataSourceOptions struct {
+	cascade        bool
+	failOnChildren bool
+}
+
+// WithDataSourceDeleteCascade deletes all descendants of the DataSource, deepest first, before the DataSource itself is deleted.
+func WithDataSourceDeleteCascade() func(options *DeleteDataSourceOptions) {
+	return func(options *DeleteDataSourceOptions) {
+		options.cascade = true
+	}
+}
+
+// WithDataSourceDeleteFailOnChildren returns a types.ErrInvalidInput listing the child DataSources
+// instead of deleting a DataSource that still has children.
+func WithDataSourceDeleteFailOnChildren() func(options *DeleteDataSourceOptions) {
+	return func(options *DeleteDataSourceOptions) {
+		options.failOnChildren = true
+	}
+}
+
 // DeleteDataSource deletes an existing DataSource.
+// By default, child DataSources are not taken into account.
+// WithDataSourceDeleteCascade can be used to delete all descendants as well and WithDataSourceDeleteFailOnChildren to prevent deleting a DataSource with children.
 // Returns nil if successful.
 // Otherwise, returns an error.
-func (c *DataSourceClient) DeleteDataSource(ctx context.Context, id string) error {
+func (c *DataSourceClient) DeleteDataSource(ctx context.Context, id string, ops ...func(options *DeleteDataSourceOptions)) error {
+	options := DeleteDataSourceOptions{}
+	for _, op := range ops {
+		op(&options)
+	}
+
+	if options.cascade || options.failOnChildren {
+		tree, err := c.GetDataSourceTree(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		descendants := tree.Descendants()
+
+		if options.failOnChildren && len(tree.Children) > 0 {
+			childIds := make([]string, 0, len(tree.Children))
+			for _, child := range tree.Children {
+				childIds = append(childIds, child.DataSource.Id)
+			}
+
+			return types.NewErrInvalidInput(fmt.Sprintf("data source %q has %d child data sources: %s", id, len(childIds), strings.Join(childIds, ", ")))
+		}
+
+		for i := len(descendants) - 1; i >= 0; i-- {
+			err = c.deleteDataSource(ctx, descendants[i].Id)
+			if err != nil {
+				return fmt.Errorf("delete child data source %q: %w", descendants[i].Id, err)
+			}
+		}
+	}
+
+	return c.deleteDataSource(ctx, id)
+}
+
+func (c *DataSourceClient) deleteDataSource(ctx context.Context, id string) error {
 	result, err := schema.DeleteDataSource(ctx, c.client, id)
 	if err != nil {
 		return types.NewErrClient(err)
@@ -84,10 +181,48 @@ func (c *DataSourceClient) DeleteDataSource(ctx context.Context, id string) erro
 	}
 }
 
+type AddIdentityStoreToDataSourceOptions struct {
+	includeChildren bool
+}
+
+// WithAddIdentityStoreToDataSourceChildren adds the IdentityStore to all descendants of the DataSource as well.
+func WithAddIdentityStoreToDataSourceChildren() func(options *AddIdentityStoreToDataSourceOptions) {
+	return func(options *AddIdentityStoreToDataSourceOptions) {
+		options.includeChildren = true
+	}
+}
+
 // AddIdentityStoreToDataSource adds an existing IdentityStore to an existing DataSource.
+// WithAddIdentityStoreToDataSourceChildren can be used to add the IdentityStore to all descendants of the DataSource as well.
 // Returns nil if successful.
 // Otherwise, returns an error.
-func (c *DataSourceClient) AddIdentityStoreToDataSource(ctx context.Context, dsId string, isId string) error {
+func (c *DataSourceClient) AddIdentityStoreToDataSource(ctx context.Context, dsId string, isId string, ops ...func(options *AddIdentityStoreToDataSourceOptions)) error {
+	options := AddIdentityStoreToDataSourceOptions{}
+	for _, op := range ops {
+		op(&options)
+	}
+
+	err := c.addIdentityStoreToDataSource(ctx, dsId, isId)
+	if err != nil || !options.includeChildren {
+		return err
+	}
+
+	tree, err := c.GetDataSourceTree(ctx, dsId)
+	if err != nil {
+		return err
+	}
+
+	for _, descendant := range tree.Descendants() {
+		err = c.addIdentityStoreToDataSource(ctx, descendant.Id, isId)
+		if err != nil {
+			return fmt.Errorf("add identity store to child data source %q: %w", descendant.Id, err)
+		}
+	}
+
+	return nil
+}
+
+func (c *DataSourceClient) addIdentityStoreToDataSource(ctx context.Context, dsId string, isId string) error {
 	result, err := schema.AddIdentityStoreToDataSource(ctx, c.client, dsId, isId)
 	if err != nil {
 		return types.NewErrClient(err)
@@ -220,19 +355,7 @@ func (c *DataSourceClient) ListDataSources(ctx context.Context, ops ...func(*Dat
 		return nil, nil, errors.New("unreachable")
 	}
 
-	edgeFn := func(edge *types.DataSourcePageEdgesEdge) (*string, *schema.DataSource, error) {
-		cursor := edge.Cursor
-
-		if edge.Node == nil {
-			return cursor, nil, nil
-		}
-
-		listItem := (*edge.Node).(*types.DataSourcePageEdgesEdgeNodeDataSource)
-
-		return cursor, &listItem.DataSource, nil
-	}
-
-	return internal.PaginationExecutor(ctx, loadPageFn, edgeFn)
+	return internal.PaginationExecutor(ctx, loadPageFn, dataSourcesEdgeFn)
 }
 
 // ListIdentityStores returns a list of IdentityStores for a given DataSource.
@@ -256,3 +379,9 @@ func (c *DataSourceClient) ListIdentityStores(ctx context.Context, dsId string)
 		return nil, fmt.Errorf("unexpected type '%T': %w", datasource, types.ErrUnknownType)
 	}
 }
+
+func dataSourcesEdgeFn(edge *types.DataSourcePageEdgesEdge) (*string, *schema.DataSource, error) {
+	return internal.DecodeEdgeNode(edge.Cursor, edge.Node, func(node *types.DataSourcePageEdgesEdgeNodeDataSource) *schema.DataSource {
+		return &node.DataSource
+	})
+}
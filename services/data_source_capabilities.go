@@ -0,0 +1,152 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/Khan/genqlient/graphql"
+
+	"github.com/raito-io/sdk-go/internal/schema"
+	"github.com/raito-io/sdk-go/types"
+	"github.com/raito-io/sdk-go/types/models"
+)
+
+// DataSourceCapabilities describes what a DataSource supports.
+// AccessProviderTypes contains the types of access providers that can be created on the DataSource.
+// MaskingMetadata is nil if the DataSource does not provide masking information.
+type DataSourceCapabilities struct {
+	DataSource          types.DataSource           `json:"dataSource"`
+	SupportedFeatures   []types.DataSourceFeatures `json:"supportedFeatures"`
+	AccessProviderTypes []string                   `json:"accessProviderTypes"`
+	MaskingMetadata     *types.MaskingMetadata     `json:"maskingMetadata,omitempty"`
+}
+
+// Supports returns true if the DataSource supports the given feature.
+func (c *DataSourceCapabilities) Supports(feature types.DataSourceFeatures) bool {
+	return slices.Contains(c.SupportedFeatures, feature)
+}
+
+// GetDataSourceCapabilities returns the supported features, access provider types and masking metadata of the DataSource with the given id.
+// Returns the DataSourceCapabilities if successful.
+// Otherwise, returns an error.
+func (c *DataSourceClient) GetDataSourceCapabilities(ctx context.Context, id string) (*DataSourceCapabilities, error) {
+	result, err := schema.GetDataSourceCapabilities(ctx, c.client, id)
+	if err != nil {
+		return nil, types.NewErrClient(err)
+	}
+
+	switch ds := result.DataSource.(type) {
+	case *schema.GetDataSourceCapabilitiesDataSource:
+		capabilities := DataSourceCapabilities{
+			DataSource:        ds.DataSource,
+			SupportedFeatures: ds.SupportedFeatures,
+		}
+
+		for _, apType := range ds.AccessProviderTypes {
+			if apType.Type != nil {
+				capabilities.AccessProviderTypes = append(capabilities.AccessProviderTypes, *apType.Type)
+			}
+		}
+
+		if ds.MaskingMetadata != nil {
+			capabilities.MaskingMetadata = &ds.MaskingMetadata.MaskingMetadata
+		}
+
+		return &capabilities, nil
+	case *schema.GetDataSourceCapabilitiesDataSourcePermissionDeniedError:
+		return nil, types.NewErrPermissionDenied("dataSource", ds.Message)
+	case *schema.GetDataSourceCapabilitiesDataSourceNotFoundError:
+		return nil, types.NewErrNotFound(id, ds.Typename, ds.Message)
+	default:
+		return nil, fmt.Errorf("unexpected response type: %T", result.DataSource)
+	}
+}
+
+// firstDataSourceNotSupporting returns the id of the first DataSource that does not support the given feature.
+// An empty string is returned if all DataSources support the feature.
+func firstDataSourceNotSupporting(ctx context.Context, client graphql.Client, ids []string, feature types.DataSourceFeatures) (string, error) {
+	dsClient := NewDataSourceClient(client)
+
+	for _, id := range ids {
+		capabilities, err := dsClient.GetDataSourceCapabilities(ctx, id)
+		if err != nil {
+			return "", err
+		}
+
+		if !capabilities.Supports(feature) {
+			return id, nil
+		}
+	}
+
+	return "", nil
+}
+
+// requiredDataSourceFeature returns the feature a data source needs to enforce an access provider with the given action.
+func requiredDataSourceFeature(action *models.AccessProviderAction) (types.DataSourceFeatures, bool) {
+	if action == nil {
+		return "", false
+	}
+
+	switch *action {
+	case models.AccessProviderActionMask:
+		return types.DataSourceFeaturesColumnmasking, true
+	case models.AccessProviderActionFiltered:
+		return types.DataSourceFeaturesRowfiltering, true
+	default:
+		return "", false
+	}
+}
+
+// checkAccessProviderDataSourceFeatures returns a types.ErrInvalidInput if a mask or filter targets a data source that cannot enforce it.
+// The data sources of the access provider and of the data objects in its what list are checked.
+// Data objects referenced by id are loaded once to resolve their data source.
+func checkAccessProviderDataSourceFeatures(ctx context.Context, client graphql.Client, ap *types.AccessProviderInput) error {
+	feature, required := requiredDataSourceFeature(ap.Action)
+	if !required {
+		return nil
+	}
+
+	ids := make([]string, 0, len(ap.DataSources))
+	for i := range ap.DataSources {
+		ids = appendUnique(ids, ap.DataSources[i].DataSource)
+	}
+
+	var dataObjectIds []string
+
+	for i := range ap.WhatDataObjects {
+		for j := range ap.WhatDataObjects[i].DataObjectByName {
+			ids = appendUnique(ids, ap.WhatDataObjects[i].DataObjectByName[j].Datasource)
+		}
+
+		for _, doId := range ap.WhatDataObjects[i].DataObjects {
+			if doId != nil {
+				dataObjectIds = appendUnique(dataObjectIds, *doId)
+			}
+		}
+	}
+
+	doClient := NewDataObjectClient(client)
+
+	for _, doId := range dataObjectIds {
+		dataObject, err := doClient.GetDataObject(ctx, doId)
+		if err != nil {
+			return err
+		}
+
+		if dataObject.DataSource != nil {
+			ids = appendUnique(ids, dataObject.DataSource.Id)
+		}
+	}
+
+	unsupported, err := firstDataSourceNotSupporting(ctx, client, ids, feature)
+	if err != nil {
+		return err
+	}
+
+	if unsupported != "" {
+		return types.NewErrInvalidInput(fmt.Sprintf("data source %q does not support %s, required for %s access providers", unsupported, feature, ap.Action.String()))
+	}
+
+	return nil
+}
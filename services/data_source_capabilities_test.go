@@ -0,0 +1,145 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/aws/smithy-go/ptr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/raito-io/sdk-go/types"
+	"github.com/raito-io/sdk-go/types/models"
+)
+
+func TestDataSourceClient_GetDataSourceCapabilities(t *testing.T) {
+	client := newFakeGraphqlClient()
+	client.handle("GetDataSourceCapabilities", func(variables map[string]any) string {
+		assert.Equal(t, "ds1", variables["id"])
+
+		return `{"dataSource":{"__typename":"DataSource","id":"ds1","name":"Snowflake","supportedFeatures":["ColumnMasking","RowFiltering"],` +
+			`"accessProviderTypes":[{"type":"role"},{"type":null},{"type":"databaseRole"}],"maskingMetadata":{"defaultMaskExternalName":"NULL","maskTypes":[]}}}`
+	})
+
+	dsClient := NewDataSourceClient(client)
+
+	capabilities, err := dsClient.GetDataSourceCapabilities(context.Background(), "ds1")
+	require.NoError(t, err)
+
+	assert.Equal(t, "Snowflake", capabilities.DataSource.Name)
+	assert.True(t, capabilities.Supports(types.DataSourceFeaturesColumnmasking))
+	assert.False(t, capabilities.Supports(types.DataSourceFeaturesDatasharing))
+	assert.Equal(t, []string{"role", "databaseRole"}, capabilities.AccessProviderTypes)
+	require.NotNil(t, capabilities.MaskingMetadata)
+	assert.Equal(t, ptr.String("NULL"), capabilities.MaskingMetadata.DefaultMaskExternalName)
+	assert.Equal(t, 1, client.calls("GetDataSourceCapabilities"))
+}
+
+func TestDataSourceClient_GetDataSourceCapabilities_NotFound(t *testing.T) {
+	client := newFakeGraphqlClient()
+	client.handle("GetDataSourceCapabilities", func(map[string]any) string {
+		return `{"dataSource":{"__typename":"NotFoundError","message":"not found"}}`
+	})
+
+	dsClient := NewDataSourceClient(client)
+
+	_, err := dsClient.GetDataSourceCapabilities(context.Background(), "ds1")
+
+	var notFound *types.ErrNotFound
+	require.ErrorAs(t, err, &notFound)
+}
+
+func TestAccessProviderClient_CreateAccessProvider_CapabilityCheck(t *testing.T) {
+	mask := models.AccessProviderActionMask
+	grant := models.AccessProviderActionGrant
+
+	tests := []struct {
+		name                 string
+		input                types.AccessProviderInput
+		ops                  []func(options *CreateAccessProviderOptions)
+		expectedErr          bool
+		expectedCapabilities int
+		expectedDataObjects  int
+	}{
+		{
+			name:                 "supported data source",
+			input:                types.AccessProviderInput{Action: &mask, DataSources: []types.AccessProviderDataSourceInput{{DataSource: "ds1"}}},
+			expectedCapabilities: 1,
+		},
+		{
+			name:                 "unsupported data source",
+			input:                types.AccessProviderInput{Action: &mask, DataSources: []types.AccessProviderDataSourceInput{{DataSource: "ds2"}}},
+			expectedErr:          true,
+			expectedCapabilities: 1,
+		},
+		{
+			name: "unsupported data source in the what list",
+			input: types.AccessProviderInput{
+				Action:          &mask,
+				DataSources:     []types.AccessProviderDataSourceInput{{DataSource: "ds1"}},
+				WhatDataObjects: []types.AccessProviderWhatInputDO{{DataObjectByName: []types.AccessProviderWhatDoByNameInput{{Fullname: "schema.table", Datasource: "ds2"}}}},
+			},
+			expectedErr:          true,
+			expectedCapabilities: 2,
+		},
+		{
+			name: "unsupported data source of a data object referenced by id",
+			input: types.AccessProviderInput{
+				Action:          &mask,
+				DataSources:     []types.AccessProviderDataSourceInput{{DataSource: "ds1"}},
+				WhatDataObjects: []types.AccessProviderWhatInputDO{{DataObjects: []*string{ptr.String("do-ds2"), ptr.String("do-ds2"), ptr.String("do-ds1")}}},
+			},
+			expectedErr:          true,
+			expectedCapabilities: 2,
+			expectedDataObjects:  2,
+		},
+		{
+			name:  "skip the check",
+			input: types.AccessProviderInput{Action: &mask, DataSources: []types.AccessProviderDataSourceInput{{DataSource: "ds2"}}},
+			ops:   []func(options *CreateAccessProviderOptions){WithCreateAccessProviderSkipCapabilityCheck()},
+		},
+		{
+			name:  "grants are not checked",
+			input: types.AccessProviderInput{Action: &grant, DataSources: []types.AccessProviderDataSourceInput{{DataSource: "ds2"}}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := newFakeGraphqlClient()
+			client.handle("GetDataSourceCapabilities", func(variables map[string]any) string {
+				features := `[]`
+				if variables["id"] == "ds1" {
+					features = `["ColumnMasking"]`
+				}
+
+				return fmt.Sprintf(`{"dataSource":{"__typename":"DataSource","id":%q,"supportedFeatures":%s}}`, variables["id"], features)
+			})
+			client.handle("GetDataObject", func(variables map[string]any) string {
+				id := variables["dataObjectId"].(string)
+
+				return fmt.Sprintf(`{"dataObject":{"id":%q,"dataSource":{"id":%q}}}`, id, id[3:])
+			})
+			client.handle("CreateAccessProvider", func(map[string]any) string {
+				return `{"createAccessProvider":{"__typename":"AccessProvider","id":"ap1"}}`
+			})
+
+			apClient := NewAccessProviderClient(client)
+
+			_, err := apClient.CreateAccessProvider(context.Background(), test.input, test.ops...)
+
+			if test.expectedErr {
+				var invalidInput *types.ErrInvalidInput
+				require.ErrorAs(t, err, &invalidInput)
+				assert.Equal(t, 0, client.calls("CreateAccessProvider"))
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, 1, client.calls("CreateAccessProvider"))
+			}
+
+			assert.Equal(t, test.expectedCapabilities, client.calls("GetDataSourceCapabilities"))
+			assert.Equal(t, test.expectedDataObjects, client.calls("GetDataObject"))
+		})
+	}
+}
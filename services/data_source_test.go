@@ -0,0 +1,54 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/raito-io/sdk-go/types"
+)
+
+func TestDataSourceClient_UpdateDataSource_ExpectedVersion(t *testing.T) {
+	readAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name            string
+		modifiedAt      time.Time
+		expectedErr     bool
+		expectedUpdates int
+	}{
+		{name: "unmodified", modifiedAt: readAt, expectedUpdates: 1},
+		{name: "modified by another writer", modifiedAt: readAt.Add(time.Minute), expectedErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := newFakeGraphqlClient()
+			client.handle("GetDataSource", func(map[string]any) string {
+				return fmt.Sprintf(`{"dataSource":{"__typename":"DataSource","id":"ds1","modifiedAt":%q}}`, test.modifiedAt.Format(time.RFC3339))
+			})
+			client.handle("UpdateDataSource", func(map[string]any) string {
+				return `{"updateDataSource":{"__typename":"DataSource","id":"ds1"}}`
+			})
+
+			dsClient := NewDataSourceClient(client)
+
+			result, err := dsClient.UpdateDataSource(context.Background(), "ds1", types.DataSourceInput{}, WithDataSourceExpectedVersion(readAt))
+
+			if test.expectedErr {
+				var conflictErr *types.ErrConflict
+				require.ErrorAs(t, err, &conflictErr)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, "ds1", result.Id)
+			}
+
+			assert.Equal(t, 1, client.calls("GetDataSource"))
+			assert.Equal(t, test.expectedUpdates, client.calls("UpdateDataSource"))
+		})
+	}
+}
@@ -0,0 +1,182 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/smithy-go/ptr"
+
+	"github.com/raito-io/sdk-go/internal"
+	"github.com/raito-io/sdk-go/internal/schema"
+	"github.com/raito-io/sdk-go/types"
+)
+
+// DataSourceTreeNode is a DataSource with all its child DataSources.
+type DataSourceTreeNode struct {
+	DataSource types.DataSource      `json:"dataSource"`
+	Children   []*DataSourceTreeNode `json:"children,omitempty"`
+}
+
+// Walk calls fn for the node and all its descendants, parents before their children.
+// Walking stops at the first error returned by fn.
+func (n *DataSourceTreeNode) Walk(fn func(node *DataSourceTreeNode, depth int) error) error {
+	return n.walk(fn, 0)
+}
+
+func (n *DataSourceTreeNode) walk(fn func(node *DataSourceTreeNode, depth int) error, depth int) error {
+	err := fn(n, depth)
+	if err != nil {
+		return err
+	}
+
+	for _, child := range n.Children {
+		err = child.walk(fn, depth+1)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Descendants returns all descendants of the node, parents before their children.
+func (n *DataSourceTreeNode) Descendants() []types.DataSource {
+	var descendants []types.DataSource
+
+	_ = n.Walk(func(node *DataSourceTreeNode, depth int) error {
+		if depth > 0 {
+			descendants = append(descendants, node.DataSource)
+		}
+
+		return nil
+	})
+
+	return descendants
+}
+
+// ListChildDataSources returns the DataSources that have the DataSource with the given id as parent.
+// Only direct children are returned. Use GetDataSourceTree to retrieve all descendants.
+// Other list options can be added. A filter set with WithDataSourceListFilter is combined with the parent filter.
+// A channel is returned that can be used to receive the list of DataSources.
+// To close the channel ensure to cancel the context.
+func (c *DataSourceClient) ListChildDataSources(ctx context.Context, parentId string, ops ...func(*DataSourceListOptions)) <-chan types.ListItem[types.DataSource] {
+	parentOps := make([]func(*DataSourceListOptions), 0, len(ops)+1)
+	parentOps = append(parentOps, ops...)
+	parentOps = append(parentOps, func(options *DataSourceListOptions) {
+		filter := types.DataSourceFilterInput{}
+		if options.filter != nil {
+			filter = *options.filter
+		}
+
+		filter.Parent = ptr.String(parentId)
+		options.filter = &filter
+	})
+
+	return c.ListDataSources(ctx, parentOps...)
+}
+
+// GetDataSourceTree returns the DataSource with the given id together with all its descendants.
+// Returns the root DataSourceTreeNode if successful.
+// Otherwise, returns an error.
+func (c *DataSourceClient) GetDataSourceTree(ctx context.Context, id string) (*DataSourceTreeNode, error) {
+	root, err := c.GetDataSource(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	listCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	dataSources, err := internal.CollectListItems(c.ListDataSources(listCtx))
+	if err != nil {
+		return nil, err
+	}
+
+	children := make(map[string][]types.DataSource)
+
+	for i := range dataSources {
+		if dataSources[i].Parent != nil {
+			children[dataSources[i].Parent.Id] = append(children[dataSources[i].Parent.Id], dataSources[i])
+		}
+	}
+
+	rootNode := &DataSourceTreeNode{DataSource: *root}
+	visited := map[string]struct{}{root.Id: {}}
+	queue := []*DataSourceTreeNode{rootNode}
+
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+
+		for _, child := range children[node.DataSource.Id] {
+			if _, found := visited[child.Id]; found {
+				continue
+			}
+
+			visited[child.Id] = struct{}{}
+
+			childNode := &DataSourceTreeNode{DataSource: child}
+			node.Children = append(node.Children, childNode)
+			queue = append(queue, childNode)
+		}
+	}
+
+	return rootNode, nil
+}
+
+// MoveDataSource moves the DataSource with the given id under a new parent DataSource.
+// If newParentId is nil, the DataSource is moved to the root.
+// Only the parent of the DataSource is updated, all other settings are left untouched.
+// A DataSource cannot be moved under itself or one of its descendants.
+// Returns the updated DataSource if successful.
+// Otherwise, returns an error.
+func (c *DataSourceClient) MoveDataSource(ctx context.Context, id string, newParentId *string) (*types.DataSource, error) {
+	var current *types.DataSource
+
+	if newParentId == nil {
+		dataSource, err := c.GetDataSource(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		current = dataSource
+	} else {
+		tree, err := c.GetDataSourceTree(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		err = tree.Walk(func(node *DataSourceTreeNode, _ int) error {
+			if node.DataSource.Id == *newParentId {
+				return types.NewErrInvalidInput(fmt.Sprintf("data source %q cannot be moved under itself or one of its descendants (%q)", id, *newParentId))
+			}
+
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		current = &tree.DataSource
+	}
+
+	if (current.Parent == nil && newParentId == nil) || (current.Parent != nil && newParentId != nil && current.Parent.Id == *newParentId) {
+		return current, nil
+	}
+
+	result, err := schema.MoveDataSource(ctx, c.client, id, newParentId)
+	if err != nil {
+		return nil, types.NewErrClient(err)
+	}
+
+	switch response := result.UpdateDataSource.(type) {
+	case *schema.MoveDataSourceUpdateDataSource:
+		return &response.DataSource, nil
+	case *schema.MoveDataSourceUpdateDataSourceNotFoundError:
+		return nil, types.NewErrNotFound(id, response.Typename, response.Message)
+	case *schema.MoveDataSourceUpdateDataSourcePermissionDeniedError:
+		return nil, types.NewErrPermissionDenied("updateDataSource", response.Message)
+	default:
+		return nil, fmt.Errorf("unexpected response type: %T", result.UpdateDataSource)
+	}
+}
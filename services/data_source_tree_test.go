@@ -0,0 +1,106 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/aws/smithy-go/ptr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/raito-io/sdk-go/types"
+)
+
+// newFakeDataSourceTreeClient returns a client that serves data sources with the given parents. An empty parent is a root data source.
+func newFakeDataSourceTreeClient(parents map[string]string) *fakeGraphqlClient {
+	dataSourceJSON := func(id string) string {
+		if parents[id] == "" {
+			return fmt.Sprintf(`{"__typename":"DataSource","id":%q,"name":%q}`, id, id)
+		}
+
+		return fmt.Sprintf(`{"__typename":"DataSource","id":%q,"name":%q,"parent":{"id":%q}}`, id, id, parents[id])
+	}
+
+	client := newFakeGraphqlClient()
+	client.handle("GetDataSource", func(variables map[string]any) string {
+		return `{"dataSource":` + dataSourceJSON(variables["id"].(string)) + `}`
+	})
+	client.handle("ListDataSources", func(map[string]any) string {
+		nodes := make([]string, 0, len(parents))
+		for _, id := range []string{"ds1", "ds2", "ds3", "ds4"} {
+			nodes = append(nodes, dataSourceJSON(id))
+		}
+
+		return `{"dataSources":` + pagedResult(nodes...) + `}`
+	})
+
+	return client
+}
+
+func TestDataSourceClient_MoveDataSource(t *testing.T) {
+	parents := map[string]string{"ds1": "", "ds2": "ds1", "ds3": "ds2", "ds4": ""}
+
+	tests := []struct {
+		name              string
+		id                string
+		newParentId       *string
+		expectedVariables map[string]any
+		expectedErr       bool
+	}{
+		{
+			name:              "move under another data source",
+			id:                "ds2",
+			newParentId:       ptr.String("ds4"),
+			expectedVariables: map[string]any{"id": "ds2", "parent": "ds4"},
+		},
+		{
+			name:              "move to the root",
+			id:                "ds3",
+			expectedVariables: map[string]any{"id": "ds3", "parent": nil},
+		},
+		{
+			name:        "already under the parent",
+			id:          "ds3",
+			newParentId: ptr.String("ds2"),
+		},
+		{
+			name: "already a root",
+			id:   "ds1",
+		},
+		{
+			name:        "under a descendant",
+			id:          "ds1",
+			newParentId: ptr.String("ds3"),
+			expectedErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := newFakeDataSourceTreeClient(parents)
+
+			var moveVariables map[string]any
+
+			client.handle("MoveDataSource", func(variables map[string]any) string {
+				moveVariables = variables
+
+				return `{"updateDataSource":{"__typename":"DataSource","id":"` + test.id + `"}}`
+			})
+
+			dsClient := NewDataSourceClient(client)
+
+			result, err := dsClient.MoveDataSource(context.Background(), test.id, test.newParentId)
+			if test.expectedErr {
+				var invalidInput *types.ErrInvalidInput
+				require.ErrorAs(t, err, &invalidInput)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.id, result.Id)
+			assert.Equal(t, test.expectedVariables, moveVariables, "only the parent is sent, a nil parent as explicit null")
+		})
+	}
+}
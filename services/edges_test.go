@@ -0,0 +1,155 @@
+package services
+
+import (
+	"encoding/json"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/raito-io/sdk-go/types"
+)
+
+const generatedSchemaFile = "../internal/schema/generated.go"
+
+var (
+	unionVariantsOnce sync.Once
+	unionVariants     map[string][]string
+	unionVariantsErr  error
+)
+
+// loadUnionVariants returns, per generated union interface, all GraphQL type names genqlient can decode it into.
+func loadUnionVariants(t *testing.T) map[string][]string {
+	t.Helper()
+
+	unionVariantsOnce.Do(func() {
+		file, err := parser.ParseFile(token.NewFileSet(), generatedSchemaFile, nil, 0)
+		if err != nil {
+			unionVariantsErr = err
+
+			return
+		}
+
+		unionVariants = make(map[string][]string)
+
+		for _, decl := range file.Decls {
+			funcDecl, ok := decl.(*ast.FuncDecl)
+			if !ok || funcDecl.Recv != nil || len(funcDecl.Name.Name) <= len("__unmarshal") || funcDecl.Name.Name[:len("__unmarshal")] != "__unmarshal" {
+				continue
+			}
+
+			union := funcDecl.Name.Name[len("__unmarshal"):]
+
+			ast.Inspect(funcDecl.Body, func(node ast.Node) bool {
+				caseClause, isCase := node.(*ast.CaseClause)
+				if !isCase {
+					return true
+				}
+
+				for _, expr := range caseClause.List {
+					if lit, isLit := expr.(*ast.BasicLit); isLit && lit.Kind == token.STRING {
+						// The empty case handles a missing __typename, which is not a variant.
+						if typename, _ := strconv.Unquote(lit.Value); typename != "" {
+							unionVariants[union] = append(unionVariants[union], typename)
+						}
+					}
+				}
+
+				return true
+			})
+		}
+	})
+
+	require.NoError(t, unionVariantsErr)
+
+	return unionVariants
+}
+
+type edgeFnTestCase struct {
+	name             string
+	nodeUnion        string
+	expectedTypename string
+	decode           func(data []byte) (*string, bool, error)
+}
+
+func newEdgeFnTestCase[E any, T any](name string, nodeUnion string, expectedTypename string, edgeFn func(edge *E) (*string, *T, error)) edgeFnTestCase {
+	return edgeFnTestCase{
+		name:             name,
+		nodeUnion:        nodeUnion,
+		expectedTypename: expectedTypename,
+		decode: func(data []byte) (*string, bool, error) {
+			var edge E
+
+			err := json.Unmarshal(data, &edge)
+			if err != nil {
+				return nil, false, err
+			}
+
+			cursor, item, err := edgeFn(&edge)
+
+			return cursor, item != nil, err
+		},
+	}
+}
+
+func TestEdgeFns(t *testing.T) {
+	variants := loadUnionVariants(t)
+
+	tests := []edgeFnTestCase{
+		newEdgeFnTestCase("accessProviders", "AccessProviderPageEdgesEdgeNodeItem", "AccessProvider", accessProvidersEdgeFn),
+		newEdgeFnTestCase("accessProviderWhoList", "AccessProviderWhoListEdgesEdgeNodeItem", "AccessWhoItem", accessProviderWhoListEdgeFn),
+		newEdgeFnTestCase("accessProviderWhatList", "AccessProviderWhatListEdgesEdgeNodeItem", "AccessWhatItem", accessProviderWhatListEdgeFn),
+		newEdgeFnTestCase("accessProviderWhatAccessProviderList", "AccessProviderWhatAccessProviderListEdgesEdgeNodeItem", "AccessWhatAccessProviderItem", accessProviderWhatAccessProviderListEdgeFn),
+		newEdgeFnTestCase("accessProviderAbacWhatScope", "AccessProviderWhatAbacScopeListEdgesEdgeNodeItem", "DataObject", accessProviderAbacWhatScopeEdgeFn),
+		newEdgeFnTestCase("dataObjects", "DataObjectPageEdgesEdgeNodeItem", "DataObject", dataObjectsEdgeFn),
+		newEdgeFnTestCase("dataSources", "DataSourcePageEdgesEdgeNodeItem", "DataSource", dataSourcesEdgeFn),
+		newEdgeFnTestCase("groups", "GroupPageEdgesEdgeNodeItem", "Group", groupsEdgeFn),
+		newEdgeFnTestCase("identityStores", "IdentityStorePageEdgesEdgeNodeItem", "IdentityStore", identityStoresEdgeFn),
+		newEdgeFnTestCase("roles", "RolePageEdgesEdgeNodeItem", "Role", rolesEdgeFn),
+		newEdgeFnTestCase("roleAssignments", "RoleAssignmentPageEdgesEdgeNodeItem", "RoleAssignment", roleAssignmentsEdgeFn),
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			typenames := variants[tt.nodeUnion]
+			require.Contains(t, typenames, tt.expectedTypename)
+
+			t.Run("no node", func(t *testing.T) {
+				cursor, hasItem, err := tt.decode([]byte(`{"cursor": "cursor", "node": null}`))
+
+				require.NoError(t, err)
+				assert.Equal(t, "cursor", *cursor)
+				assert.False(t, hasItem)
+			})
+
+			for _, typename := range typenames {
+				t.Run(typename, func(t *testing.T) {
+					data, err := json.Marshal(map[string]any{"cursor": "cursor", "node": map[string]any{"__typename": typename}})
+					require.NoError(t, err)
+
+					cursor, hasItem, err := tt.decode(data)
+
+					if typename == tt.expectedTypename {
+						require.NoError(t, err)
+						assert.Equal(t, "cursor", *cursor)
+						assert.True(t, hasItem)
+
+						return
+					}
+
+					var nodeErr *types.ErrUnexpectedNodeType
+
+					require.ErrorAs(t, err, &nodeErr)
+					assert.Equal(t, typename, *nodeErr.Typename)
+					assert.Equal(t, "cursor", *cursor)
+					assert.False(t, hasItem)
+				})
+			}
+		})
+	}
+}
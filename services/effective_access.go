@@ -0,0 +1,432 @@
+package services
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/smithy-go/ptr"
+
+	"github.com/raito-io/sdk-go/internal"
+	"github.com/raito-io/sdk-go/types"
+	"github.com/raito-io/sdk-go/types/models"
+)
+
+type EffectiveAccessStepType string
+
+const (
+	// EffectiveAccessStepTypeUser indicates the user is a direct who item of the next access provider in the path.
+	EffectiveAccessStepTypeUser EffectiveAccessStepType = "User"
+	// EffectiveAccessStepTypeGroup indicates a group of the user is a who item of the next access provider in the path.
+	EffectiveAccessStepTypeGroup EffectiveAccessStepType = "Group"
+	// EffectiveAccessStepTypeAccessProvider is an access provider that directly grants access to the user or one of its groups.
+	EffectiveAccessStepTypeAccessProvider EffectiveAccessStepType = "AccessProvider"
+	// EffectiveAccessStepTypeWhoInheritance is an access provider that has the previous access provider in the path as who item.
+	EffectiveAccessStepTypeWhoInheritance EffectiveAccessStepType = "WhoInheritance"
+	// EffectiveAccessStepTypeWhatInheritance is an access provider that is part of the what of the previous access provider in the path.
+	EffectiveAccessStepTypeWhatInheritance EffectiveAccessStepType = "WhatInheritance"
+)
+
+// EffectiveAccessStep is a single step in the path that gives a user access to a data object.
+type EffectiveAccessStep struct {
+	Type EffectiveAccessStepType `json:"type"`
+	Id   string                  `json:"id"`
+	Name string                  `json:"name"`
+}
+
+// EffectiveAccessItem describes the access of a user to a single data object through a single access provider.
+type EffectiveAccessItem struct {
+	DataObject         types.DataObject      `json:"dataObject"`
+	AccessProviderId   string                `json:"accessProviderId"`
+	AccessProviderName string                `json:"accessProviderName"`
+	Path               []EffectiveAccessStep `json:"path"`
+	Permissions        []string              `json:"permissions"`
+	GlobalPermissions  []string              `json:"globalPermissions"`
+	ExpiresAt          *time.Time            `json:"expiresAt,omitempty"`
+	// ExpiresAfter is the shortest relative expiry (expiresAfter) of the who items along the path.
+	// It is set for who items that expire relative to the moment they are granted, which is not exposed by the API.
+	ExpiresAfter *int64 `json:"expiresAfter,omitempty"`
+}
+
+// EffectiveAccessResult is the result of UserClient.EffectiveAccess.
+type EffectiveAccessResult struct {
+	UserId string                `json:"userId"`
+	Items  []EffectiveAccessItem `json:"items"`
+}
+
+// WriteJSON writes the result as JSON to w.
+func (r *EffectiveAccessResult) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	err := encoder.Encode(r)
+	if err != nil {
+		return fmt.Errorf("encode effective access: %w", err)
+	}
+
+	return nil
+}
+
+// WriteCSV writes the result as CSV to w, with one row per item.
+func (r *EffectiveAccessResult) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+
+	err := writer.Write([]string{"userId", "dataObjectId", "dataObjectFullName", "dataObjectType", "accessProviderId", "accessProviderName", "permissions", "globalPermissions", "expiresAt", "expiresAfter", "path"})
+	if err != nil {
+		return fmt.Errorf("write csv header: %w", err)
+	}
+
+	for i := range r.Items {
+		item := &r.Items[i]
+
+		expiresAt := ""
+		if item.ExpiresAt != nil {
+			expiresAt = item.ExpiresAt.Format(time.RFC3339)
+		}
+
+		expiresAfter := ""
+		if item.ExpiresAfter != nil {
+			expiresAfter = strconv.FormatInt(*item.ExpiresAfter, 10)
+		}
+
+		path := make([]string, 0, len(item.Path))
+		for _, step := range item.Path {
+			path = append(path, fmt.Sprintf("%s:%s", step.Type, step.Name))
+		}
+
+		err = writer.Write([]string{r.UserId, item.DataObject.Id, item.DataObject.FullName, item.DataObject.Type, item.AccessProviderId, item.AccessProviderName, strings.Join(item.Permissions, "|"), strings.Join(item.GlobalPermissions, "|"), expiresAt, expiresAfter, strings.Join(path, " > ")})
+		if err != nil {
+			return fmt.Errorf("write csv row: %w", err)
+		}
+	}
+
+	writer.Flush()
+
+	if err = writer.Error(); err != nil {
+		return fmt.Errorf("flush csv: %w", err)
+	}
+
+	return nil
+}
+
+type EffectiveAccessOptions struct {
+	filter      *types.AccessProviderFilterInput
+	concurrency int
+}
+
+// WithEffectiveAccessFilter sets the filter of the access providers that are taken into account.
+// By default, only active grant and purpose access providers are used.
+func WithEffectiveAccessFilter(filter *types.AccessProviderFilterInput) func(options *EffectiveAccessOptions) {
+	return func(options *EffectiveAccessOptions) {
+		options.filter = filter
+	}
+}
+
+// WithEffectiveAccessConcurrency sets the maximum number of concurrent requests used to load access providers.
+func WithEffectiveAccessConcurrency(concurrency int) func(options *EffectiveAccessOptions) {
+	return func(options *EffectiveAccessOptions) {
+		options.concurrency = concurrency
+	}
+}
+
+type effectiveAccessNode struct {
+	path         []EffectiveAccessStep
+	expiresAt    *time.Time
+	expiresAfter *int64
+}
+
+type effectiveAccessEdge struct {
+	to           string
+	stepType     EffectiveAccessStepType
+	expiresAt    *time.Time
+	expiresAfter *int64
+}
+
+// effectiveAccessGraph holds the access providers, with their who lists and what access providers, that are used to resolve the access of a user.
+type effectiveAccessGraph struct {
+	user                EffectiveAccessStep
+	groups              map[string]types.Group
+	accessProviderIds   []string
+	accessProviderNames map[string]string
+	whoLists            map[string][]types.AccessProviderWhoListItem
+	whatAccessProviders map[string][]types.AccessWhatAccessProviderItem
+}
+
+// resolve returns the access providers that grant access to the user, with the path through which the access is granted.
+// Access providers that are directly granted to the user or one of its groups are reached first, then who and what inheritance is followed breadth-first.
+// Promise who items and who items or what access providers that expired before now are ignored.
+func (g *effectiveAccessGraph) resolve(now time.Time) map[string]*effectiveAccessNode {
+	reached := make(map[string]*effectiveAccessNode)
+	edges := make(map[string][]effectiveAccessEdge)
+
+	var queue []string
+
+	for _, apId := range g.accessProviderIds {
+		whoItems := g.whoLists[apId]
+
+		for i := range whoItems {
+			if whoItems[i].Type == types.AccessWhoItemTypeWhopromise || isExpired(whoItems[i].ExpiresAt, now) {
+				continue
+			}
+
+			var principal *EffectiveAccessStep
+
+			switch item := whoItems[i].Item.(type) {
+			case *types.AccessProviderWhoListItemItemUser:
+				if item.Id == g.user.Id {
+					principal = &g.user
+				}
+			case *types.AccessProviderWhoListItemItemGroup:
+				if group, found := g.groups[item.Id]; found {
+					principal = &EffectiveAccessStep{Type: EffectiveAccessStepTypeGroup, Id: group.Id, Name: group.Name}
+				}
+			case *types.AccessProviderWhoListItemItemAccessProvider:
+				if _, found := g.accessProviderNames[item.Id]; found {
+					edges[item.Id] = append(edges[item.Id], effectiveAccessEdge{to: apId, stepType: EffectiveAccessStepTypeWhoInheritance, expiresAt: whoItems[i].ExpiresAt, expiresAfter: whoItems[i].ExpiresAfter})
+				}
+			}
+
+			if principal == nil {
+				continue
+			}
+
+			if current, found := reached[apId]; found && (current.path[0].Type == EffectiveAccessStepTypeUser || principal.Type == EffectiveAccessStepTypeGroup) {
+				continue
+			}
+
+			if _, found := reached[apId]; !found {
+				queue = append(queue, apId)
+			}
+
+			reached[apId] = &effectiveAccessNode{
+				path:         []EffectiveAccessStep{*principal, {Type: EffectiveAccessStepTypeAccessProvider, Id: apId, Name: g.accessProviderNames[apId]}},
+				expiresAt:    whoItems[i].ExpiresAt,
+				expiresAfter: whoItems[i].ExpiresAfter,
+			}
+		}
+
+		whatAccessProviders := g.whatAccessProviders[apId]
+
+		for i := range whatAccessProviders {
+			if whatAccessProviders[i].AccessProvider == nil || isExpired(whatAccessProviders[i].ExpiresAt, now) {
+				continue
+			}
+
+			to := whatAccessProviders[i].AccessProvider.Id
+			if _, found := g.accessProviderNames[to]; found {
+				edges[apId] = append(edges[apId], effectiveAccessEdge{to: to, stepType: EffectiveAccessStepTypeWhatInheritance, expiresAt: whatAccessProviders[i].ExpiresAt})
+			}
+		}
+	}
+
+	for len(queue) > 0 {
+		apId := queue[0]
+		queue = queue[1:]
+
+		node := reached[apId]
+
+		for _, edge := range edges[apId] {
+			if _, found := reached[edge.to]; found {
+				continue
+			}
+
+			path := make([]EffectiveAccessStep, len(node.path), len(node.path)+1)
+			copy(path, node.path)
+
+			reached[edge.to] = &effectiveAccessNode{
+				path:         append(path, EffectiveAccessStep{Type: edge.stepType, Id: edge.to, Name: g.accessProviderNames[edge.to]}),
+				expiresAt:    earliestTime(node.expiresAt, edge.expiresAt),
+				expiresAfter: shortestDuration(node.expiresAfter, edge.expiresAfter),
+			}
+
+			queue = append(queue, edge.to)
+		}
+	}
+
+	return reached
+}
+
+// EffectiveAccess returns all data objects the user with the given id can access.
+// Access is resolved through the who items of the access providers, the groups of the user (including ancestor groups),
+// access providers in the who items (who inheritance) and access providers in the what (what inheritance).
+// Promise who items are ignored as they do not grant access, as are who items and what access providers that already expired.
+// For each data object and granting access provider, the path that causes the access, the permissions and the earliest expiry along the path are returned.
+// Who items that expire relative to the moment they were granted are returned with ExpiresAfter, as that moment is not known.
+// Who and what lists are loaded once per access provider, with at most internal.MaxConcurrentRequests concurrent requests unless WithEffectiveAccessConcurrency is set.
+func (c *UserClient) EffectiveAccess(ctx context.Context, userId string, ops ...func(options *EffectiveAccessOptions)) (*EffectiveAccessResult, error) {
+	options := EffectiveAccessOptions{
+		filter: &types.AccessProviderFilterInput{
+			States:  []models.AccessProviderState{models.AccessProviderStateActive},
+			Actions: []models.AccessProviderAction{models.AccessProviderActionGrant, models.AccessProviderActionPurpose},
+		},
+		concurrency: internal.MaxConcurrentRequests,
+	}
+
+	for _, op := range ops {
+		op(&options)
+	}
+
+	user, err := c.GetUser(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	groups, err := c.userGroups(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	loader := newAccessProviderLoader(c.client)
+
+	accessProviders, err := loader.listAccessProviders(ctx, options.filter)
+	if err != nil {
+		return nil, err
+	}
+
+	accessProviderNames := make(map[string]string, len(accessProviders))
+	accessProviderIds := make([]string, 0, len(accessProviders))
+
+	for i := range accessProviders {
+		accessProviderNames[accessProviders[i].Id] = accessProviders[i].Name
+		accessProviderIds = append(accessProviderIds, accessProviders[i].Id)
+	}
+
+	sort.Strings(accessProviderIds)
+
+	err = forEachConcurrent(ctx, accessProviderIds, options.concurrency, func(ctx context.Context, id string) error {
+		if _, loadErr := loader.whoList(ctx, id); loadErr != nil {
+			return loadErr
+		}
+
+		_, loadErr := loader.whatAccessProviderList(ctx, id)
+
+		return loadErr
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	graph := effectiveAccessGraph{
+		user:                EffectiveAccessStep{Type: EffectiveAccessStepTypeUser, Id: user.Id, Name: user.Name},
+		groups:              groups,
+		accessProviderIds:   accessProviderIds,
+		accessProviderNames: accessProviderNames,
+		whoLists:            make(map[string][]types.AccessProviderWhoListItem, len(accessProviderIds)),
+		whatAccessProviders: make(map[string][]types.AccessWhatAccessProviderItem, len(accessProviderIds)),
+	}
+
+	for _, apId := range accessProviderIds {
+		graph.whoLists[apId], _ = loader.whoList(ctx, apId)
+		graph.whatAccessProviders[apId], _ = loader.whatAccessProviderList(ctx, apId)
+	}
+
+	reached := graph.resolve(time.Now())
+
+	reachedIds := make([]string, 0, len(reached))
+	for apId := range reached {
+		reachedIds = append(reachedIds, apId)
+	}
+
+	sort.Strings(reachedIds)
+
+	err = forEachConcurrent(ctx, reachedIds, options.concurrency, func(ctx context.Context, id string) error {
+		_, loadErr := loader.whatDataObjectList(ctx, id)
+
+		return loadErr
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := EffectiveAccessResult{UserId: userId}
+
+	for _, apId := range reachedIds {
+		node := reached[apId]
+		whatItems, _ := loader.whatDataObjectList(ctx, apId)
+
+		for i := range whatItems {
+			if whatItems[i].DataObject == nil {
+				continue
+			}
+
+			result.Items = append(result.Items, EffectiveAccessItem{
+				DataObject:         whatItems[i].DataObject.DataObject,
+				AccessProviderId:   apId,
+				AccessProviderName: accessProviderNames[apId],
+				Path:               node.path,
+				Permissions:        derefStrings(whatItems[i].Permissions),
+				GlobalPermissions:  derefStrings(whatItems[i].GlobalPermissions),
+				ExpiresAt:          node.expiresAt,
+				ExpiresAfter:       node.expiresAfter,
+			})
+		}
+	}
+
+	return &result, nil
+}
+
+// userGroups returns all groups the user is a member of, including ancestor groups.
+func (c *UserClient) userGroups(ctx context.Context, userId string) (map[string]types.Group, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	groupClient := NewGroupClient(c.client)
+
+	groups, err := internal.CollectListItems(groupClient.ListGroups(ctx, WithGroupListFilter(&types.GroupFilterInput{User: ptr.String(userId), IncludeAncestors: ptr.Bool(true)})))
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]types.Group, len(groups))
+	for i := range groups {
+		result[groups[i].Id] = groups[i]
+	}
+
+	return result, nil
+}
+
+func earliestTime(a *time.Time, b *time.Time) *time.Time {
+	if a == nil {
+		return b
+	}
+
+	if b == nil || a.Before(*b) {
+		return a
+	}
+
+	return b
+}
+
+func shortestDuration(a *int64, b *int64) *int64 {
+	if a == nil {
+		return b
+	}
+
+	if b == nil || *a < *b {
+		return a
+	}
+
+	return b
+}
+
+func isExpired(expiresAt *time.Time, now time.Time) bool {
+	return expiresAt != nil && !expiresAt.After(now)
+}
+
+func derefStrings(values []*string) []string {
+	result := make([]string, 0, len(values))
+
+	for _, value := range values {
+		if value != nil {
+			result = append(result, *value)
+		}
+	}
+
+	return result
+}
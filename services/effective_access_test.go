@@ -0,0 +1,227 @@
+package services
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/aws/smithy-go/ptr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/raito-io/sdk-go/internal/schema"
+	"github.com/raito-io/sdk-go/types"
+)
+
+var effectiveAccessNow = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func userWhoItem(id string) types.AccessProviderWhoListItem {
+	return types.AccessProviderWhoListItem{
+		Type: types.AccessWhoItemTypeWhogrant,
+		Item: &types.AccessProviderWhoListItemItemUser{User: schema.User{Id: id, Name: id}},
+	}
+}
+
+func groupWhoItem(id string) types.AccessProviderWhoListItem {
+	return types.AccessProviderWhoListItem{
+		Type: types.AccessWhoItemTypeWhogrant,
+		Item: &types.AccessProviderWhoListItemItemGroup{Id: id, Name: id},
+	}
+}
+
+func accessProviderWhoItem(id string) types.AccessProviderWhoListItem {
+	return types.AccessProviderWhoListItem{
+		Type: types.AccessWhoItemTypeWhogrant,
+		Item: &types.AccessProviderWhoListItemItemAccessProvider{Id: id, Name: id},
+	}
+}
+
+func whatAccessProviderItem(id string, expiresAt *time.Time) types.AccessWhatAccessProviderItem {
+	return types.AccessWhatAccessProviderItem{
+		AccessProvider: &schema.AccessWhatAccessProviderItemAccessProvider{AccessProvider: schema.AccessProvider{Id: id}},
+		ExpiresAt:      expiresAt,
+	}
+}
+
+func withExpiresAt(item types.AccessProviderWhoListItem, t time.Time) types.AccessProviderWhoListItem {
+	item.ExpiresAt = &t
+
+	return item
+}
+
+func withExpiresAfter(item types.AccessProviderWhoListItem, d int64) types.AccessProviderWhoListItem {
+	item.ExpiresAfter = &d
+
+	return item
+}
+
+func newEffectiveAccessGraph(whoLists map[string][]types.AccessProviderWhoListItem, whatAccessProviders map[string][]types.AccessWhatAccessProviderItem, apIds ...string) *effectiveAccessGraph {
+	sort.Strings(apIds)
+
+	names := make(map[string]string, len(apIds))
+	for _, id := range apIds {
+		names[id] = id
+	}
+
+	return &effectiveAccessGraph{
+		user:                EffectiveAccessStep{Type: EffectiveAccessStepTypeUser, Id: "u1", Name: "u1"},
+		groups:              map[string]types.Group{"g1": {Id: "g1", Name: "g1"}},
+		accessProviderIds:   apIds,
+		accessProviderNames: names,
+		whoLists:            whoLists,
+		whatAccessProviders: whatAccessProviders,
+	}
+}
+
+// pathIds returns the ids of the steps of the path of each reached access provider.
+func pathIds(reached map[string]*effectiveAccessNode) map[string][]string {
+	result := make(map[string][]string, len(reached))
+
+	for apId, node := range reached {
+		for _, step := range node.path {
+			result[apId] = append(result[apId], string(step.Type)+":"+step.Id)
+		}
+	}
+
+	return result
+}
+
+func TestEffectiveAccessGraph_Resolve(t *testing.T) {
+	tests := []struct {
+		name                string
+		whoLists            map[string][]types.AccessProviderWhoListItem
+		whatAccessProviders map[string][]types.AccessWhatAccessProviderItem
+		apIds               []string
+		expected            map[string][]string
+	}{
+		{
+			name: "direct and group grants, the user grant is preferred",
+			whoLists: map[string][]types.AccessProviderWhoListItem{
+				"ap1": {groupWhoItem("g1"), userWhoItem("u1")},
+				"ap2": {groupWhoItem("g1")},
+				"ap3": {groupWhoItem("g2"), userWhoItem("u2")},
+			},
+			apIds: []string{"ap1", "ap2", "ap3"},
+			expected: map[string][]string{
+				"ap1": {"User:u1", "AccessProvider:ap1"},
+				"ap2": {"Group:g1", "AccessProvider:ap2"},
+			},
+		},
+		{
+			name: "promise and expired who items do not grant access",
+			whoLists: map[string][]types.AccessProviderWhoListItem{
+				"ap1": {{Type: types.AccessWhoItemTypeWhopromise, Item: &types.AccessProviderWhoListItemItemUser{User: schema.User{Id: "u1"}}}},
+				"ap2": {withExpiresAt(userWhoItem("u1"), effectiveAccessNow.Add(-time.Hour))},
+				"ap3": {withExpiresAt(userWhoItem("u1"), effectiveAccessNow.Add(time.Hour))},
+			},
+			apIds: []string{"ap1", "ap2", "ap3"},
+			expected: map[string][]string{
+				"ap3": {"User:u1", "AccessProvider:ap3"},
+			},
+		},
+		{
+			name: "diamond",
+			whoLists: map[string][]types.AccessProviderWhoListItem{
+				"ap1": {userWhoItem("u1")},
+				"ap2": {accessProviderWhoItem("ap1")},
+				"ap3": {accessProviderWhoItem("ap1")},
+				"ap4": {accessProviderWhoItem("ap2")},
+			},
+			whatAccessProviders: map[string][]types.AccessWhatAccessProviderItem{
+				"ap3": {whatAccessProviderItem("ap4", nil)},
+			},
+			apIds: []string{"ap1", "ap2", "ap3", "ap4"},
+			expected: map[string][]string{
+				"ap1": {"User:u1", "AccessProvider:ap1"},
+				"ap2": {"User:u1", "AccessProvider:ap1", "WhoInheritance:ap2"},
+				"ap3": {"User:u1", "AccessProvider:ap1", "WhoInheritance:ap3"},
+				"ap4": {"User:u1", "AccessProvider:ap1", "WhoInheritance:ap2", "WhoInheritance:ap4"},
+			},
+		},
+		{
+			name: "cycle",
+			whoLists: map[string][]types.AccessProviderWhoListItem{
+				"ap1": {userWhoItem("u1"), accessProviderWhoItem("ap3")},
+				"ap2": {accessProviderWhoItem("ap1")},
+				"ap3": {accessProviderWhoItem("ap2")},
+			},
+			apIds: []string{"ap1", "ap2", "ap3"},
+			expected: map[string][]string{
+				"ap1": {"User:u1", "AccessProvider:ap1"},
+				"ap2": {"User:u1", "AccessProvider:ap1", "WhoInheritance:ap2"},
+				"ap3": {"User:u1", "AccessProvider:ap1", "WhoInheritance:ap2", "WhoInheritance:ap3"},
+			},
+		},
+		{
+			name: "cycle without a grant is not reached",
+			whoLists: map[string][]types.AccessProviderWhoListItem{
+				"ap1": {accessProviderWhoItem("ap2")},
+				"ap2": {accessProviderWhoItem("ap1")},
+			},
+			apIds:    []string{"ap1", "ap2"},
+			expected: map[string][]string{},
+		},
+		{
+			name: "expired inheritance is not followed",
+			whoLists: map[string][]types.AccessProviderWhoListItem{
+				"ap1": {userWhoItem("u1")},
+				"ap2": {withExpiresAt(accessProviderWhoItem("ap1"), effectiveAccessNow)},
+			},
+			whatAccessProviders: map[string][]types.AccessWhatAccessProviderItem{
+				"ap1": {whatAccessProviderItem("ap3", ptr.Time(effectiveAccessNow.Add(-time.Minute)))},
+			},
+			apIds: []string{"ap1", "ap2", "ap3"},
+			expected: map[string][]string{
+				"ap1": {"User:u1", "AccessProvider:ap1"},
+			},
+		},
+		{
+			name: "inheritance outside the analysed access providers is not followed",
+			whoLists: map[string][]types.AccessProviderWhoListItem{
+				"ap1": {userWhoItem("u1")},
+				"ap2": {accessProviderWhoItem("ap1")},
+			},
+			apIds: []string{"ap1"},
+			expected: map[string][]string{
+				"ap1": {"User:u1", "AccessProvider:ap1"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			graph := newEffectiveAccessGraph(test.whoLists, test.whatAccessProviders, test.apIds...)
+
+			assert.Equal(t, test.expected, pathIds(graph.resolve(effectiveAccessNow)))
+		})
+	}
+}
+
+func TestEffectiveAccessGraph_Resolve_ExpiryPropagation(t *testing.T) {
+	in1h := effectiveAccessNow.Add(time.Hour)
+	in2h := effectiveAccessNow.Add(2 * time.Hour)
+	in3h := effectiveAccessNow.Add(3 * time.Hour)
+
+	graph := newEffectiveAccessGraph(map[string][]types.AccessProviderWhoListItem{
+		"ap1": {withExpiresAt(userWhoItem("u1"), in2h)},
+		"ap2": {withExpiresAt(accessProviderWhoItem("ap1"), in3h)},
+		"ap3": {withExpiresAfter(groupWhoItem("g1"), 7200)},
+		"ap4": {withExpiresAfter(accessProviderWhoItem("ap3"), 3600)},
+		"ap5": {withExpiresAfter(accessProviderWhoItem("ap3"), 86400)},
+	}, map[string][]types.AccessWhatAccessProviderItem{
+		"ap2": {whatAccessProviderItem("ap6", &in1h)},
+	}, "ap1", "ap2", "ap3", "ap4", "ap5", "ap6")
+
+	reached := graph.resolve(effectiveAccessNow)
+	require.Len(t, reached, 6)
+
+	assert.Equal(t, &in2h, reached["ap1"].expiresAt)
+	assert.Equal(t, &in2h, reached["ap2"].expiresAt, "the earliest expiry along the path is kept")
+	assert.Equal(t, &in1h, reached["ap6"].expiresAt, "an earlier what inheritance expiry wins")
+	assert.Nil(t, reached["ap1"].expiresAfter)
+
+	assert.Nil(t, reached["ap3"].expiresAt)
+	assert.Equal(t, ptr.Int64(7200), reached["ap3"].expiresAfter)
+	assert.Equal(t, ptr.Int64(3600), reached["ap4"].expiresAfter, "the shortest relative expiry along the path is kept")
+	assert.Equal(t, ptr.Int64(7200), reached["ap5"].expiresAfter)
+}
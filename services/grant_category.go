@@ -3,8 +3,12 @@ package services
 import (
 	"context"
 	"fmt"
+	"reflect"
+	"slices"
+	"sort"
 
 	"github.com/Khan/genqlient/graphql"
+	"github.com/aws/smithy-go/ptr"
 
 	"github.com/raito-io/sdk-go/internal/schema"
 	"github.com/raito-io/sdk-go/types"
@@ -12,11 +16,14 @@ import (
 
 type GrantCategoryClient struct {
 	client graphql.Client
+
+	categories *grantCategoryCache
 }
 
 func NewGrantCategoryClient(client graphql.Client) GrantCategoryClient {
 	return GrantCategoryClient{
-		client: client,
+		client:     client,
+		categories: &grantCategoryCache{},
 	}
 }
 
@@ -31,6 +38,8 @@ func (a *GrantCategoryClient) CreateGrantCategory(ctx context.Context, category
 
 	switch response := result.CreateGrantCategory.(type) {
 	case *schema.CreateGrantCategoryCreateGrantCategory:
+		a.categories.invalidate("")
+
 		return &response.GrantCategoryDetails, nil
 	case *schema.CreateGrantCategoryCreateGrantCategoryPermissionDeniedError:
 		return nil, types.NewErrPermissionDenied("createGrantCategory", response.Message)
@@ -54,6 +63,9 @@ func (a *GrantCategoryClient) UpdateGrantCategory(ctx context.Context, id string
 
 	switch response := result.UpdateGrantCategory.(type) {
 	case *schema.UpdateGrantCategoryUpdateGrantCategory:
+		a.categories.invalidate(id)
+		a.categories.invalidate("")
+
 		return &response.GrantCategoryDetails, nil
 	case *schema.UpdateGrantCategoryUpdateGrantCategoryPermissionDeniedError:
 		return nil, types.NewErrPermissionDenied("updateGrantCategory", response.Message)
@@ -78,6 +90,8 @@ func (a *GrantCategoryClient) DeleteGrantCategory(ctx context.Context, id string
 	switch response := result.DeleteGrantCategory.(type) {
 	case *schema.DeleteGrantCategoryDeleteGrantCategory:
 		if response.GetSuccess() {
+			a.categories.invalidate(id)
+
 			return nil
 		} else {
 			return types.NewErrClient(fmt.Errorf("deleteGrantCategory failed"))
@@ -133,3 +147,193 @@ func (a *GrantCategoryClient) ListGrantCategories(ctx context.Context) ([]types.
 
 	return grantCategories, nil
 }
+
+// EnsureGrantCategory makes sure a GrantCategory with the name of the given input exists and matches the input.
+// If no GrantCategory with that name exists, it is created.
+// If it exists, it is only updated when the input changes any of its settings. Fields that are not set in the input keep their current value.
+// Returns the resulting GrantCategory if successful.
+// Otherwise, returns an error.
+func (a *GrantCategoryClient) EnsureGrantCategory(ctx context.Context, category types.GrantCategoryInput) (*types.GrantCategoryDetails, error) {
+	if category.Name == nil || *category.Name == "" {
+		return nil, types.NewErrInvalidInput("grant category name is required")
+	}
+
+	grantCategories, err := a.ListGrantCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range grantCategories {
+		if grantCategories[i].Name != *category.Name {
+			continue
+		}
+
+		current := GrantCategoryDetailsToInput(&grantCategories[i])
+		desired := mergeGrantCategoryInput(current, category)
+
+		if reflect.DeepEqual(normalizeGrantCategoryInput(current), normalizeGrantCategoryInput(desired)) {
+			return &grantCategories[i], nil
+		}
+
+		return a.UpdateGrantCategory(ctx, grantCategories[i].Id, desired)
+	}
+
+	return a.CreateGrantCategory(ctx, category)
+}
+
+// GrantCategoryDetailsToInput converts the GrantCategoryDetails into a GrantCategoryInput.
+// The resulting input can be used to update the GrantCategory without losing any of its settings.
+func GrantCategoryDetailsToInput(details *types.GrantCategoryDetails) types.GrantCategoryInput {
+	input := types.GrantCategoryInput{
+		Name:                 ptr.String(details.Name),
+		Description:          ptr.String(details.Description),
+		Icon:                 ptr.String(details.Icon),
+		CanCreate:            ptr.Bool(details.CanCreate),
+		CanRequestAccess:     ptr.Bool(details.CanRequestAccess),
+		DescriptionMandatory: ptr.Bool(details.DescriptionMandatory),
+		AllowDuplicateNames:  ptr.Bool(details.AllowDuplicateNames),
+		MultiDataSource:      ptr.Bool(details.MultiDataSource),
+		AllowedWhoItems: &types.GrantCategoryAllowedWhoItemsInput{
+			User:        details.AllowedWhoItems.User,
+			Group:       details.AllowedWhoItems.Group,
+			Inheritance: details.AllowedWhoItems.Inheritance,
+			Self:        details.AllowedWhoItems.Self,
+			Categories:  append([]string(nil), details.AllowedWhoItems.Categories...),
+		},
+		AllowedWhatItems: &types.GrantCategoryAllowedWhatItemsInput{
+			DataObject: details.AllowedWhatItems.DataObject,
+		},
+		NameRegEx:          copyStringPtr(details.NameRegEx),
+		NameRegExMsg:       copyStringPtr(details.NameRegExMsg),
+		NamingHintRegEx:    copyStringPtr(details.NamingHintRegEx),
+		NamingHintRegExMsg: copyStringPtr(details.NamingHintRegExMsg),
+		LocksOnCreate:      append(make([]types.AccessProviderLock, 0, len(details.LocksOnCreate)), details.LocksOnCreate...),
+	}
+
+	input.DefaultTypePerDataSource = make([]types.GrantCategoryTypeForDataSourceInput, 0, len(details.DefaultTypePerDataSource))
+	for i := range details.DefaultTypePerDataSource {
+		input.DefaultTypePerDataSource = append(input.DefaultTypePerDataSource, types.GrantCategoryTypeForDataSourceInput{
+			DataSource: details.DefaultTypePerDataSource[i].DataSource,
+			Type:       details.DefaultTypePerDataSource[i].Type,
+		})
+	}
+
+	return input
+}
+
+// normalizeGrantCategoryInput returns a copy of the input that can be compared with reflect.DeepEqual.
+// Slices are sorted, and nil and empty values are made equal.
+func normalizeGrantCategoryInput(input types.GrantCategoryInput) types.GrantCategoryInput {
+	result := input
+
+	for _, field := range []**string{&result.NameRegEx, &result.NameRegExMsg, &result.NamingHintRegEx, &result.NamingHintRegExMsg} {
+		if *field == nil {
+			*field = ptr.String("")
+		}
+	}
+
+	if input.AllowedWhoItems != nil {
+		allowedWhoItems := *input.AllowedWhoItems
+		allowedWhoItems.Categories = sortedCopy(allowedWhoItems.Categories)
+		result.AllowedWhoItems = &allowedWhoItems
+	}
+
+	result.LocksOnCreate = sortedCopy(input.LocksOnCreate)
+
+	result.DefaultTypePerDataSource = append(make([]types.GrantCategoryTypeForDataSourceInput, 0, len(input.DefaultTypePerDataSource)), input.DefaultTypePerDataSource...)
+	sort.Slice(result.DefaultTypePerDataSource, func(i, j int) bool {
+		if result.DefaultTypePerDataSource[i].DataSource != result.DefaultTypePerDataSource[j].DataSource {
+			return result.DefaultTypePerDataSource[i].DataSource < result.DefaultTypePerDataSource[j].DataSource
+		}
+
+		return result.DefaultTypePerDataSource[i].Type < result.DefaultTypePerDataSource[j].Type
+	})
+
+	return result
+}
+
+func sortedCopy[T ~string](values []T) []T {
+	result := append(make([]T, 0, len(values)), values...)
+	slices.Sort(result)
+
+	return result
+}
+
+func copyStringPtr(s *string) *string {
+	if s == nil {
+		return nil
+	}
+
+	return ptr.String(*s)
+}
+
+// mergeGrantCategoryInput returns the base input with all fields that are set in the override replaced.
+func mergeGrantCategoryInput(base types.GrantCategoryInput, override types.GrantCategoryInput) types.GrantCategoryInput {
+	result := base
+
+	if override.Name != nil {
+		result.Name = override.Name
+	}
+
+	if override.Description != nil {
+		result.Description = override.Description
+	}
+
+	if override.Icon != nil {
+		result.Icon = override.Icon
+	}
+
+	if override.CanCreate != nil {
+		result.CanCreate = override.CanCreate
+	}
+
+	if override.CanRequestAccess != nil {
+		result.CanRequestAccess = override.CanRequestAccess
+	}
+
+	if override.DescriptionMandatory != nil {
+		result.DescriptionMandatory = override.DescriptionMandatory
+	}
+
+	if override.AllowDuplicateNames != nil {
+		result.AllowDuplicateNames = override.AllowDuplicateNames
+	}
+
+	if override.MultiDataSource != nil {
+		result.MultiDataSource = override.MultiDataSource
+	}
+
+	if override.DefaultTypePerDataSource != nil {
+		result.DefaultTypePerDataSource = override.DefaultTypePerDataSource
+	}
+
+	if override.AllowedWhoItems != nil {
+		result.AllowedWhoItems = override.AllowedWhoItems
+	}
+
+	if override.AllowedWhatItems != nil {
+		result.AllowedWhatItems = override.AllowedWhatItems
+	}
+
+	if override.NameRegEx != nil {
+		result.NameRegEx = override.NameRegEx
+	}
+
+	if override.NameRegExMsg != nil {
+		result.NameRegExMsg = override.NameRegExMsg
+	}
+
+	if override.NamingHintRegEx != nil {
+		result.NamingHintRegEx = override.NamingHintRegEx
+	}
+
+	if override.NamingHintRegExMsg != nil {
+		result.NamingHintRegExMsg = override.NamingHintRegExMsg
+	}
+
+	if override.LocksOnCreate != nil {
+		result.LocksOnCreate = override.LocksOnCreate
+	}
+
+	return result
+}
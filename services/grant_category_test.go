@@ -0,0 +1,90 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/smithy-go/ptr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/raito-io/sdk-go/types"
+)
+
+const testGrantCategoryJSON = `{"__typename":"GrantCategory","id":"cat1","name":"Analytics","description":"","icon":"",
+	"defaultTypePerDataSource":[{"DataSource":"ds2","Type":"role"},{"DataSource":"ds1","Type":"grant"}],
+	"allowedWhoItems":{"user":true,"group":true,"inheritance":false,"self":false,"categories":["b","a"]},
+	"allowedWhatItems":{"dataObject":true},
+	"locksOnCreate":["WhoLock","NameLock"]}`
+
+func TestGrantCategoryClient_EnsureGrantCategory(t *testing.T) {
+	tests := []struct {
+		name           string
+		input          types.GrantCategoryInput
+		expectedUpdate bool
+	}{
+		{
+			name: "same settings in a different order",
+			input: types.GrantCategoryInput{
+				Name: ptr.String("Analytics"),
+				DefaultTypePerDataSource: []types.GrantCategoryTypeForDataSourceInput{
+					{DataSource: "ds1", Type: "grant"},
+					{DataSource: "ds2", Type: "role"},
+				},
+				AllowedWhoItems: &types.GrantCategoryAllowedWhoItemsInput{User: true, Group: true, Categories: []string{"a", "b"}},
+				LocksOnCreate:   []types.AccessProviderLock{types.AccessProviderLockNamelock, types.AccessProviderLockWholock},
+			},
+		},
+		{
+			name: "empty regular expression equals an unset one",
+			input: types.GrantCategoryInput{
+				Name:      ptr.String("Analytics"),
+				NameRegEx: ptr.String(""),
+			},
+		},
+		{
+			name: "changed setting",
+			input: types.GrantCategoryInput{
+				Name:          ptr.String("Analytics"),
+				LocksOnCreate: []types.AccessProviderLock{types.AccessProviderLockWholock},
+			},
+			expectedUpdate: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := newFakeGraphqlClient()
+			client.handle("ListGrantCategories", func(map[string]any) string {
+				return `{"grantCategories":[` + testGrantCategoryJSON + `]}`
+			})
+
+			var updateVariables map[string]any
+
+			client.handle("UpdateGrantCategory", func(variables map[string]any) string {
+				updateVariables = variables
+
+				return `{"updateGrantCategory":` + testGrantCategoryJSON + `}`
+			})
+
+			categoryClient := NewGrantCategoryClient(client)
+
+			result, err := categoryClient.EnsureGrantCategory(context.Background(), test.input)
+			require.NoError(t, err)
+
+			assert.Equal(t, "cat1", result.Id)
+
+			if !test.expectedUpdate {
+				assert.Equal(t, 0, client.calls("UpdateGrantCategory"))
+
+				return
+			}
+
+			require.Equal(t, 1, client.calls("UpdateGrantCategory"))
+
+			input, _ := updateVariables["input"].(map[string]any)
+			assert.Equal(t, []any{"WhoLock"}, input["locksOnCreate"])
+			assert.Equal(t, map[string]any{"user": true, "group": true, "inheritance": false, "self": false, "categories": []any{"b", "a"}}, input["allowedWhoItems"], "settings that are not in the input are kept")
+		})
+	}
+}
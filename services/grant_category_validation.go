@@ -0,0 +1,294 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/Khan/genqlient/graphql"
+
+	"github.com/raito-io/sdk-go/types"
+)
+
+// grantCategoryCache caches grant categories by id.
+// The default grant category is stored with an empty id.
+type grantCategoryCache struct {
+	mutex      sync.Mutex
+	categories map[string]*types.GrantCategoryDetails
+}
+
+func (c *grantCategoryCache) get(id string) (*types.GrantCategoryDetails, bool) {
+	if c == nil {
+		return nil, false
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	category, found := c.categories[id]
+
+	return category, found
+}
+
+func (c *grantCategoryCache) set(id string, category *types.GrantCategoryDetails) {
+	if c == nil {
+		return
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.categories == nil {
+		c.categories = make(map[string]*types.GrantCategoryDetails)
+	}
+
+	c.categories[id] = category
+}
+
+func (c *grantCategoryCache) invalidate(id string) {
+	if c == nil {
+		return
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	delete(c.categories, id)
+
+	if defaultCategory, found := c.categories[""]; found && defaultCategory.Id == id {
+		delete(c.categories, "")
+	}
+}
+
+// ValidateAccessProviderInput validates an AccessProviderInput against the rules of its grant category before it is sent to Raito.
+// If the input has no category, the default grant category is used.
+// The following rules are validated: the name and naming hint regexes, the allowed who and what items,
+// the number of data sources for categories that do not support multiple data sources and a mandatory description.
+// The regexes must match the whole name or naming hint.
+// Data source and recipient who items resolve to users, so they are only allowed if the grant category allows users.
+// If the grant category only allows the creator itself (self) as user, the current user is loaded and is the only allowed user who item.
+// Data sources of what items referenced by id are loaded to count the data sources, once per data object and only
+// while the input does not span multiple data sources yet.
+// Grant categories are cached by the GrantCategoryClient.
+// Returns nil if the input is valid, a *types.ErrValidation containing all violations if it is not.
+// Otherwise, returns an error.
+func (a *GrantCategoryClient) ValidateAccessProviderInput(ctx context.Context, input types.AccessProviderInput) error {
+	categoryId := ""
+	if input.Category != nil {
+		categoryId = *input.Category
+	}
+
+	category, err := a.cachedGrantCategory(ctx, categoryId)
+	if err != nil {
+		return err
+	}
+
+	violations, err := validateAccessProviderInput(ctx, a.client, category, &input)
+	if err != nil {
+		return err
+	}
+
+	if len(violations) > 0 {
+		return types.NewErrValidation(violations)
+	}
+
+	return nil
+}
+
+func (a *GrantCategoryClient) cachedGrantCategory(ctx context.Context, id string) (*types.GrantCategoryDetails, error) {
+	if category, found := a.categories.get(id); found {
+		return category, nil
+	}
+
+	if id != "" {
+		category, err := a.GetGrantCategory(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		a.categories.set(id, category)
+
+		return category, nil
+	}
+
+	grantCategories, err := a.ListGrantCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range grantCategories {
+		if grantCategories[i].IsDefault {
+			a.categories.set("", &grantCategories[i])
+
+			return &grantCategories[i], nil
+		}
+	}
+
+	return nil, types.NewErrNotFound("", nil, "no default grant category found")
+}
+
+func validateAccessProviderInput(ctx context.Context, client graphql.Client, category *types.GrantCategoryDetails, input *types.AccessProviderInput) ([]types.ValidationViolation, error) {
+	var violations []types.ValidationViolation
+
+	addViolation := func(field string, format string, args ...any) {
+		violations = append(violations, types.ValidationViolation{Field: field, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if category.NameRegEx != nil && *category.NameRegEx != "" && input.Name != nil {
+		matched, err := matchesWholeString(*category.NameRegEx, *input.Name)
+		if err != nil {
+			return nil, fmt.Errorf("invalid name regex of grant category %q: %w", category.Name, err)
+		}
+
+		if !matched {
+			addViolation("name", "%s", regexViolationMessage(category.NameRegEx, category.NameRegExMsg))
+		}
+	}
+
+	if category.NamingHintRegEx != nil && *category.NamingHintRegEx != "" && input.NamingHint != nil {
+		matched, err := matchesWholeString(*category.NamingHintRegEx, *input.NamingHint)
+		if err != nil {
+			return nil, fmt.Errorf("invalid naming hint regex of grant category %q: %w", category.Name, err)
+		}
+
+		if !matched {
+			addViolation("namingHint", "%s", regexViolationMessage(category.NamingHintRegEx, category.NamingHintRegExMsg))
+		}
+	}
+
+	if category.DescriptionMandatory && (input.Description == nil || strings.TrimSpace(*input.Description) == "") {
+		addViolation("description", "a description is mandatory in grant category %q", category.Name)
+	}
+
+	allowedWho := category.AllowedWhoItems
+	var accessProviderClient *AccessProviderClient
+	var currentUserId *string
+
+	for i := range input.WhoItems {
+		whoItem := &input.WhoItems[i]
+
+		switch {
+		case whoItem.User != nil && !allowedWho.User && allowedWho.Self:
+			if currentUserId == nil {
+				userClient := NewUserClient(client)
+
+				currentUser, err := userClient.GetCurrentUser(ctx)
+				if err != nil {
+					return nil, err
+				}
+
+				currentUserId = &currentUser.Id
+			}
+
+			if *whoItem.User != *currentUserId {
+				addViolation(fmt.Sprintf("whoItems[%d].user", i), "only the current user is allowed as user who item in grant category %q", category.Name)
+			}
+		case whoItem.User != nil && !allowedWho.User:
+			addViolation(fmt.Sprintf("whoItems[%d].user", i), "users are not allowed as who item in grant category %q", category.Name)
+		case whoItem.Group != nil && !allowedWho.Group:
+			addViolation(fmt.Sprintf("whoItems[%d].group", i), "groups are not allowed as who item in grant category %q", category.Name)
+		case whoItem.DataSource != nil && !allowedWho.User:
+			addViolation(fmt.Sprintf("whoItems[%d].dataSource", i), "data sources are not allowed as who item in grant category %q as it does not allow users", category.Name)
+		case whoItem.Recipient != nil && !allowedWho.User:
+			addViolation(fmt.Sprintf("whoItems[%d].recipient", i), "recipients are not allowed as who item in grant category %q as it does not allow users", category.Name)
+		case whoItem.AccessProvider != nil && !allowedWho.Inheritance:
+			addViolation(fmt.Sprintf("whoItems[%d].accessProvider", i), "inheritance is not allowed in grant category %q", category.Name)
+		case whoItem.AccessProvider != nil && len(allowedWho.Categories) > 0:
+			if accessProviderClient == nil {
+				c := NewAccessProviderClient(client)
+				accessProviderClient = &c
+			}
+
+			accessProvider, err := accessProviderClient.GetAccessProvider(ctx, *whoItem.AccessProvider)
+			if err != nil {
+				return nil, err
+			}
+
+			if accessProvider.Category == nil || (!slices.Contains(allowedWho.Categories, accessProvider.Category.Id) && !slices.Contains(allowedWho.Categories, accessProvider.Category.Name)) {
+				addViolation(fmt.Sprintf("whoItems[%d].accessProvider", i), "access provider %q is not in a grant category that can be inherited in grant category %q", *whoItem.AccessProvider, category.Name)
+			}
+		}
+	}
+
+	if !category.AllowedWhatItems.DataObject {
+		if len(input.WhatDataObjects) > 0 {
+			addViolation("whatDataObjects", "data objects are not allowed as what item in grant category %q", category.Name)
+		}
+
+		if input.WhatAbacRule != nil {
+			addViolation("whatAbacRule", "data objects are not allowed as what item in grant category %q", category.Name)
+		}
+	}
+
+	if !category.MultiDataSource {
+		dataSources := make([]string, 0, len(input.DataSources))
+
+		for i := range input.DataSources {
+			dataSources = appendUnique(dataSources, input.DataSources[i].DataSource)
+		}
+
+		for i := range input.WhoItems {
+			if input.WhoItems[i].DataSource != nil {
+				dataSources = appendUnique(dataSources, *input.WhoItems[i].DataSource)
+			}
+		}
+
+		var dataObjectIds []string
+
+		for i := range input.WhatDataObjects {
+			for j := range input.WhatDataObjects[i].DataObjectByName {
+				dataSources = appendUnique(dataSources, input.WhatDataObjects[i].DataObjectByName[j].Datasource)
+			}
+
+			for _, doId := range input.WhatDataObjects[i].DataObjects {
+				if doId != nil {
+					dataObjectIds = appendUnique(dataObjectIds, *doId)
+				}
+			}
+		}
+
+		dataObjectClient := NewDataObjectClient(client)
+
+		for _, doId := range dataObjectIds {
+			if len(dataSources) > 1 {
+				break
+			}
+
+			dataObject, err := dataObjectClient.GetDataObject(ctx, doId)
+			if err != nil {
+				return nil, err
+			}
+
+			if dataObject.DataSource != nil {
+				dataSources = appendUnique(dataSources, dataObject.DataSource.Id)
+			}
+		}
+
+		if len(dataSources) > 1 {
+			addViolation("dataSources", "grant category %q does not allow multiple data sources, got %d", category.Name, len(dataSources))
+		}
+	}
+
+	return violations, nil
+}
+
+// matchesWholeString returns true if the regular expression matches the whole value instead of a part of it.
+func matchesWholeString(regex string, value string) (bool, error) {
+	matched, err := regexp.MatchString("^(?:"+regex+")$", value)
+	if err != nil {
+		return false, fmt.Errorf("compile regex: %w", err)
+	}
+
+	return matched, nil
+}
+
+func regexViolationMessage(regex *string, msg *string) string {
+	if msg != nil && *msg != "" {
+		return *msg
+	}
+
+	return fmt.Sprintf("does not match %q", *regex)
+}
@@ -0,0 +1,193 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/aws/smithy-go/ptr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/raito-io/sdk-go/internal/schema"
+	"github.com/raito-io/sdk-go/types"
+)
+
+func TestValidateAccessProviderInput(t *testing.T) {
+	category := &types.GrantCategoryDetails{
+		Name:            "Analytics",
+		NameRegEx:       ptr.String("[a-z]+"),
+		NamingHintRegEx: ptr.String("ap_[a-z]+|hint"),
+		AllowedWhoItems: schema.GrantCategoryDetailsAllowedWhoItemsGrantCategoryAllowedWhoItems{
+			GrantCategoryAllowedWhoItems: schema.GrantCategoryAllowedWhoItems{Group: true},
+		},
+		AllowedWhatItems: schema.GrantCategoryDetailsAllowedWhatItemsGrantCategoryAllowedWhatItems{
+			GrantCategoryAllowedWhatItems: schema.GrantCategoryAllowedWhatItems{DataObject: true},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		input    types.AccessProviderInput
+		expected []string
+	}{
+		{
+			name:  "valid",
+			input: types.AccessProviderInput{Name: ptr.String("analytics"), NamingHint: ptr.String("hint")},
+		},
+		{
+			name:     "regexes must match the whole value",
+			input:    types.AccessProviderInput{Name: ptr.String("Analytics"), NamingHint: ptr.String("ap_x_hint")},
+			expected: []string{"name", "namingHint"},
+		},
+		{
+			name: "data source and recipient who items require users",
+			input: types.AccessProviderInput{WhoItems: []types.WhoItemInput{
+				{Group: ptr.String("g1")},
+				{DataSource: ptr.String("ds1")},
+				{Recipient: ptr.String("r1")},
+			}},
+			expected: []string{"whoItems[1].dataSource", "whoItems[2].recipient"},
+		},
+		{
+			name: "data objects referenced by id count as data sources",
+			input: types.AccessProviderInput{
+				DataSources: []types.AccessProviderDataSourceInput{{DataSource: "ds1"}},
+				WhatDataObjects: []types.AccessProviderWhatInputDO{
+					{DataObjects: []*string{ptr.String("do-ds1")}},
+					{DataObjects: []*string{ptr.String("do-ds2")}},
+				},
+			},
+			expected: []string{"dataSources"},
+		},
+		{
+			name: "data objects in the same data source",
+			input: types.AccessProviderInput{
+				DataSources:     []types.AccessProviderDataSourceInput{{DataSource: "ds1"}},
+				WhatDataObjects: []types.AccessProviderWhatInputDO{{DataObjects: []*string{ptr.String("do-ds1")}}},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := newFakeGraphqlClient()
+			client.handle("GetDataObject", func(variables map[string]any) string {
+				id := variables["dataObjectId"].(string)
+
+				return fmt.Sprintf(`{"dataObject":{"id":%q,"dataSource":{"id":%q}}}`, id, id[3:])
+			})
+
+			violations, err := validateAccessProviderInput(context.Background(), client, category, &test.input)
+			require.NoError(t, err)
+
+			var fields []string
+			for _, violation := range violations {
+				fields = append(fields, violation.Field)
+			}
+
+			assert.Equal(t, test.expected, fields)
+		})
+	}
+}
+
+func TestValidateAccessProviderInput_Self(t *testing.T) {
+	category := &types.GrantCategoryDetails{
+		Name: "Personal",
+		AllowedWhoItems: schema.GrantCategoryDetailsAllowedWhoItemsGrantCategoryAllowedWhoItems{
+			GrantCategoryAllowedWhoItems: schema.GrantCategoryAllowedWhoItems{Self: true},
+		},
+	}
+
+	client := newFakeGraphqlClient()
+	client.handle("CurrentUser", func(map[string]any) string {
+		return `{"currentUser":{"id":"u1","name":"Jane"}}`
+	})
+
+	input := types.AccessProviderInput{WhoItems: []types.WhoItemInput{
+		{User: ptr.String("u1")},
+		{User: ptr.String("u2")},
+		{Group: ptr.String("g1")},
+	}}
+
+	violations, err := validateAccessProviderInput(context.Background(), client, category, &input)
+	require.NoError(t, err)
+
+	var fields []string
+	for _, violation := range violations {
+		fields = append(fields, violation.Field)
+	}
+
+	assert.Equal(t, []string{"whoItems[1].user", "whoItems[2].group"}, fields)
+	assert.Equal(t, 1, client.calls("CurrentUser"), "the current user is loaded once")
+}
+
+func TestValidateAccessProviderInput_DataObjectLookups(t *testing.T) {
+	category := &types.GrantCategoryDetails{
+		Name: "Analytics",
+		AllowedWhatItems: schema.GrantCategoryDetailsAllowedWhatItemsGrantCategoryAllowedWhatItems{
+			GrantCategoryAllowedWhatItems: schema.GrantCategoryAllowedWhatItems{DataObject: true},
+		},
+	}
+
+	client := newFakeGraphqlClient()
+	client.handle("GetDataObject", func(variables map[string]any) string {
+		id := variables["dataObjectId"].(string)
+
+		return fmt.Sprintf(`{"dataObject":{"id":%q,"dataSource":{"id":%q}}}`, id, id[3:])
+	})
+
+	input := types.AccessProviderInput{
+		DataSources: []types.AccessProviderDataSourceInput{{DataSource: "ds1"}},
+		WhatDataObjects: []types.AccessProviderWhatInputDO{
+			{DataObjects: []*string{ptr.String("do-ds1"), ptr.String("do-ds1")}},
+			{DataObjects: []*string{ptr.String("do-ds1"), ptr.String("do-ds2"), ptr.String("do-ds3")}},
+		},
+	}
+
+	violations, err := validateAccessProviderInput(context.Background(), client, category, &input)
+	require.NoError(t, err)
+	require.Len(t, violations, 1)
+	assert.Equal(t, "dataSources", violations[0].Field)
+
+	assert.Equal(t, 2, client.calls("GetDataObject"), "each data object is loaded once and the lookups stop at the second data source")
+}
+
+func TestGrantCategoryClient_InvalidateDefaultCategory(t *testing.T) {
+	client := newFakeGraphqlClient()
+	client.handle("ListGrantCategories", func(map[string]any) string {
+		return `{"grantCategories":[{"__typename":"GrantCategory","id":"cat1","name":"Default","isDefault":true,"allowedWhoItems":{"user":true}}]}`
+	})
+	client.handle("CreateGrantCategory", func(map[string]any) string {
+		return `{"createGrantCategory":{"__typename":"GrantCategory","id":"cat2","name":"Other"}}`
+	})
+	client.handle("UpdateGrantCategory", func(map[string]any) string {
+		return `{"updateGrantCategory":{"__typename":"GrantCategory","id":"cat2","name":"Other"}}`
+	})
+
+	categoryClient := NewGrantCategoryClient(client)
+	ctx := context.Background()
+
+	require.NoError(t, categoryClient.ValidateAccessProviderInput(ctx, types.AccessProviderInput{}))
+	require.NoError(t, categoryClient.ValidateAccessProviderInput(ctx, types.AccessProviderInput{}))
+	assert.Equal(t, 1, client.calls("ListGrantCategories"), "the default category is cached")
+
+	_, err := categoryClient.CreateGrantCategory(ctx, types.GrantCategoryInput{Name: ptr.String("Other")})
+	require.NoError(t, err)
+	require.NoError(t, categoryClient.ValidateAccessProviderInput(ctx, types.AccessProviderInput{}))
+	assert.Equal(t, 2, client.calls("ListGrantCategories"))
+
+	_, err = categoryClient.UpdateGrantCategory(ctx, "cat2", types.GrantCategoryInput{Name: ptr.String("Other")})
+	require.NoError(t, err)
+	require.NoError(t, categoryClient.ValidateAccessProviderInput(ctx, types.AccessProviderInput{}))
+	assert.Equal(t, 3, client.calls("ListGrantCategories"))
+
+	client.handle("UpdateGrantCategory", func(map[string]any) string {
+		return `{"updateGrantCategory":{"__typename":"PermissionDeniedError","message":"denied"}}`
+	})
+
+	_, err = categoryClient.UpdateGrantCategory(ctx, "cat2", types.GrantCategoryInput{Name: ptr.String("Other")})
+	require.Error(t, err)
+	require.NoError(t, categoryClient.ValidateAccessProviderInput(ctx, types.AccessProviderInput{}))
+	assert.Equal(t, 3, client.calls("ListGrantCategories"), "a failed update keeps the cache")
+}
@@ -70,17 +70,11 @@ func (g GroupClient) ListGroups(ctx context.Context, ops ...func(options *GroupL
 		return &output.Groups.PageInfo.PageInfo, output.Groups.Edges, nil
 	}
 
-	edgeFn := func(edge *types.GroupPageEdgesEdge) (*string, *schema.Group, error) {
-		cursor := edge.Cursor
-
-		if edge.Node == nil {
-			return cursor, nil, nil
-		}
-
-		listItem := (*edge.Node).(*types.GroupPageEdgesEdgeNodeGroup)
-
-		return cursor, &listItem.Group, nil
-	}
+	return internal.PaginationExecutor(ctx, loadPageFn, groupsEdgeFn)
+}
 
-	return internal.PaginationExecutor(ctx, loadPageFn, edgeFn)
+func groupsEdgeFn(edge *types.GroupPageEdgesEdge) (*string, *schema.Group, error) {
+	return internal.DecodeEdgeNode(edge.Cursor, edge.Node, func(node *types.GroupPageEdgesEdgeNodeGroup) *schema.Group {
+		return &node.Group
+	})
 }
@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/Khan/genqlient/graphql"
 	"github.com/aws/smithy-go/ptr"
@@ -45,10 +46,49 @@ func (c *IdentityStoreClient) CreateIdentityStore(ctx context.Context, is types.
 	}
 }
 
+type UpdateIdentityStoreOptions struct {
+	unmodifiedSince *time.Time
+}
+
+// WithIdentityStoreIfUnmodifiedSince only updates the IdentityStore if it was not modified after t.
+// Pass the modifiedAt of the IdentityStore that was read to detect concurrent updates.
+// If the IdentityStore was modified after t, a types.ErrConflict is returned.
+// The check is best-effort: the client reads the current modifiedAt before sending the update,
+// so a concurrent write between that read and the update is not detected and can still be overwritten.
+func WithIdentityStoreIfUnmodifiedSince(t time.Time) func(options *UpdateIdentityStoreOptions) {
+	return func(options *UpdateIdentityStoreOptions) {
+		options.unmodifiedSince = &t
+	}
+}
+
+// WithIdentityStoreExpectedVersion only updates the IdentityStore if its current version is the expected version.
+// The version of a IdentityStore is its modifiedAt, so this is equivalent to WithIdentityStoreIfUnmodifiedSince(modifiedAt).
+func WithIdentityStoreExpectedVersion(modifiedAt time.Time) func(options *UpdateIdentityStoreOptions) {
+	return WithIdentityStoreIfUnmodifiedSince(modifiedAt)
+}
+
 // UpdateIdentityStore updates an existing IdentityStore for a given DataSource.
 // Returns the updated IdentityStore if successful.
+// WithIdentityStoreIfUnmodifiedSince can be used to detect most concurrent modifications, but does not guarantee protection against them.
 // Otherwise, returns an error.
-func (c *IdentityStoreClient) UpdateIdentityStore(ctx context.Context, id string, is types.IdentityStoreInput) (*types.IdentityStore, error) {
+func (c *IdentityStoreClient) UpdateIdentityStore(ctx context.Context, id string, is types.IdentityStoreInput, ops ...func(options *UpdateIdentityStoreOptions)) (*types.IdentityStore, error) {
+	options := UpdateIdentityStoreOptions{}
+	for _, op := range ops {
+		op(&options)
+	}
+
+	if options.unmodifiedSince != nil {
+		current, err := c.GetIdentityStore(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		err = checkUnmodifiedSince("identityStore", id, current.ModifiedAt, options.unmodifiedSince)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	result, err := schema.UpdateIdentityStore(ctx, c.client, id, is)
 	if err != nil {
 		return nil, types.NewErrClient(err)
@@ -179,17 +219,31 @@ func (c *IdentityStoreClient) ListIdentityStores(ctx context.Context, ops ...fun
 		}
 	}
 
-	edgeFn := func(edge *types.IdentityStorePageEdgesEdge) (*string, *types.IdentityStore, error) {
-		cursor := edge.Cursor
+	return internal.PaginationExecutor(ctx, loadPageFn, identityStoresEdgeFn)
+}
 
-		if edge.Node == nil {
-			return cursor, nil, nil
-		}
+func identityStoresEdgeFn(edge *types.IdentityStorePageEdgesEdge) (*string, *types.IdentityStore, error) {
+	return internal.DecodeEdgeNode(edge.Cursor, edge.Node, func(node *types.IdentityStorePageEdgesEdgeNodeIdentityStore) *types.IdentityStore {
+		return &node.IdentityStore
+	})
+}
 
-		listItem := (*edge.Node).(*types.IdentityStorePageEdgesEdgeNodeIdentityStore)
+// ListIdentityStoreGroups returns the Groups of the IdentityStore with the given id.
+// Other list options can be added. A filter set with WithGroupListFilter is combined with the identity store filter.
+// A channel is returned that can be used to receive the list of Groups.
+// To close the channel ensure to cancel the context.
+func (c *IdentityStoreClient) ListIdentityStoreGroups(ctx context.Context, id string, ops ...func(options *GroupListOptions)) <-chan types.ListItem[types.Group] {
+	identityStoreOps := make([]func(*GroupListOptions), 0, len(ops)+1)
+	identityStoreOps = append(identityStoreOps, ops...)
+	identityStoreOps = append(identityStoreOps, func(options *GroupListOptions) {
+		filter := types.GroupFilterInput{}
+		if options.filter != nil {
+			filter = *options.filter
+		}
 
-		return cursor, &listItem.IdentityStore, nil
-	}
+		filter.IdentityStores = []string{id}
+		options.filter = &filter
+	})
 
-	return internal.PaginationExecutor(ctx, loadPageFn, edgeFn)
+	return NewGroupClient(c.client).ListGroups(ctx, identityStoreOps...)
 }
@@ -0,0 +1,93 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/aws/smithy-go/ptr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/raito-io/sdk-go/internal"
+	"github.com/raito-io/sdk-go/types"
+)
+
+func TestIdentityStoreClient_UpdateIdentityStore_ExpectedVersion(t *testing.T) {
+	readAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name            string
+		modifiedAt      time.Time
+		expectedErr     bool
+		expectedUpdates int
+	}{
+		{name: "unmodified", modifiedAt: readAt, expectedUpdates: 1},
+		{name: "modified by another writer", modifiedAt: readAt.Add(time.Minute), expectedErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := newFakeGraphqlClient()
+			client.handle("GetIdentityStore", func(map[string]any) string {
+				return fmt.Sprintf(`{"identityStore":{"__typename":"IdentityStore","id":"is1","modifiedAt":%q}}`, test.modifiedAt.Format(time.RFC3339))
+			})
+			client.handle("UpdateIdentityStore", func(map[string]any) string {
+				return `{"updateIdentityStore":{"__typename":"IdentityStore","id":"is1"}}`
+			})
+
+			isClient := NewIdentityStoreClient(client)
+
+			result, err := isClient.UpdateIdentityStore(context.Background(), "is1", types.IdentityStoreInput{}, WithIdentityStoreExpectedVersion(readAt))
+
+			if test.expectedErr {
+				var conflictErr *types.ErrConflict
+				require.ErrorAs(t, err, &conflictErr)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, "is1", result.Id)
+			}
+
+			assert.Equal(t, 1, client.calls("GetIdentityStore"))
+			assert.Equal(t, test.expectedUpdates, client.calls("UpdateIdentityStore"))
+		})
+	}
+}
+
+func TestIdentityStoreClient_ListIdentityStoreGroups(t *testing.T) {
+	var cursors []any
+
+	client := newFakeGraphqlClient()
+	client.handle("ListGroups", func(variables map[string]any) string {
+		filter := variables["filter"].(map[string]any)
+		assert.Equal(t, []any{"is1"}, filter["identityStores"])
+		assert.Equal(t, "analysts", filter["search"], "the filter of the caller is kept")
+
+		cursors = append(cursors, variables["after"])
+
+		if variables["after"] == nil {
+			return `{"groups":{"__typename":"PagedResult","pageInfo":{"hasNextPage":true},"edges":[` +
+				`{"cursor":"c1","node":{"__typename":"Group","id":"g1"}},` +
+				`{"cursor":"c2","node":{"__typename":"Group","id":"g2"}}]}}`
+		}
+
+		return `{"groups":` + pagedResult(`{"__typename":"Group","id":"g3"}`) + `}`
+	})
+
+	isClient := NewIdentityStoreClient(client)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	groups, err := internal.CollectListItems(isClient.ListIdentityStoreGroups(ctx, "is1", WithGroupListFilter(&types.GroupFilterInput{Search: ptr.String("analysts")})))
+	require.NoError(t, err)
+
+	var ids []string
+	for i := range groups {
+		ids = append(ids, groups[i].Id)
+	}
+
+	assert.Equal(t, []string{"g1", "g2", "g3"}, ids)
+	assert.Equal(t, []any{nil, "c2"}, cursors)
+}
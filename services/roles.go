@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
 
 	"github.com/Khan/genqlient/graphql"
 	"github.com/aws/smithy-go/ptr"
@@ -13,6 +14,10 @@ import (
 	"github.com/raito-io/sdk-go/types"
 )
 
+// OwnerRoleName is the name of the role that is used to model ownership of data objects, data sources and access providers.
+// The id of the role is not exposed by the API, use GetOwnerRole to look it up.
+const OwnerRoleName = "Owner"
+
 type RoleClient struct {
 	client graphql.Client
 }
@@ -73,19 +78,69 @@ func (c *RoleClient) ListRoles(ctx context.Context, ops ...func(*RoleListOptions
 		return &output.Roles.PageInfo.PageInfo, output.Roles.Edges, nil
 	}
 
-	edgeFn := func(edge *types.RolePageEdgesEdge) (*string, *schema.Role, error) {
-		cursor := edge.Cursor
+	return internal.PaginationExecutor(ctx, loadPageFn, rolesEdgeFn)
+}
+
+// ValidateRoleScope returns a types.ErrInvalidInput if the role can not be assigned on the scope type.
+// Only the global roles are loaded: a global role can only be assigned globally, any other role only on a resource.
+// The API does not expose on which resource types a non-global role can be assigned, so those restrictions,
+// as well as unknown roles, are left to the server to reject.
+// The Assign methods call ValidateRoleScope before the role is assigned.
+func (c *RoleClient) ValidateRoleScope(ctx context.Context, roleId string, scopeType RoleScopeType) error {
+	globalRoles, err := c.globalRoleIds(ctx)
+	if err != nil {
+		return err
+	}
 
-		if edge.Node == nil {
-			return cursor, nil, nil
+	return checkRoleScope(globalRoles, roleId, scopeType)
+}
+
+// GetOwnerRole returns the role that is used to model ownership.
+// The role is looked up by OwnerRoleName among the roles that are not global.
+func (c *RoleClient) GetOwnerRole(ctx context.Context) (*types.Role, error) {
+	roles, err := c.listRoles(ctx, &types.RoleFilterInput{Search: ptr.String(OwnerRoleName), IsGlobal: ptr.Bool(false)})
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range roles {
+		if roles[i].Name == OwnerRoleName {
+			return &roles[i], nil
 		}
+	}
+
+	return nil, types.NewErrNotFound(OwnerRoleName, ptr.String("Role"), "no role with name "+OwnerRoleName)
+}
+
+func (c *RoleClient) listRoles(ctx context.Context, filter *types.RoleFilterInput) ([]types.Role, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	return internal.CollectListItems(c.ListRoles(ctx, WithRoleListFilter(filter)))
+}
+
+func (c *RoleClient) globalRoleIds(ctx context.Context) (map[string]struct{}, error) {
+	roles, err := c.listRoles(ctx, &types.RoleFilterInput{IsGlobal: ptr.Bool(true)})
+	if err != nil {
+		return nil, fmt.Errorf("load global roles: %w", err)
+	}
+
+	result := make(map[string]struct{}, len(roles))
+	for i := range roles {
+		result[roles[i].Id] = struct{}{}
+	}
+
+	return result, nil
+}
 
-		listItem := (*edge.Node).(*types.RolePageEdgesEdgeNodeRole)
+func checkRoleScope(globalRoles map[string]struct{}, roleId string, scopeType RoleScopeType) error {
+	_, isGlobal := globalRoles[roleId]
 
-		return cursor, &listItem.Role, nil
+	if isGlobal != (scopeType == RoleScopeTypeGlobal) {
+		return types.NewErrInvalidInput(fmt.Sprintf("role %q can not be assigned on scope %q", roleId, scopeType))
 	}
 
-	return internal.PaginationExecutor(ctx, loadPageFn, edgeFn)
+	return nil
 }
 
 type RoleAssignmentListOptions struct {
@@ -291,7 +346,13 @@ func (c *RoleClient) ListRoleAssignmentsOnUser(ctx context.Context, userId strin
 // roleId is the id of the role to assign.
 // isId is the id of the identity store to assign the role to.
 // to is a list of user ids to assign the role to.
+// A types.ErrInvalidInput is returned, without assigning the role, if ValidateRoleScope rejects the role for the scope.
 func (c *RoleClient) AssignRoleOnIdentityStore(ctx context.Context, roleId string, isId string, to ...string) (*types.Role, error) {
+	err := c.ValidateRoleScope(ctx, roleId, RoleScopeTypeIdentityStore)
+	if err != nil {
+		return nil, err
+	}
+
 	output, err := schema.AssignRoleOnIdentityStore(ctx, c.client, roleId, isId, to)
 	if err != nil {
 		return nil, types.NewErrClient(err)
@@ -313,7 +374,13 @@ func (c *RoleClient) AssignRoleOnIdentityStore(ctx context.Context, roleId strin
 // roleId is the id of the role to assign.
 // isId is the id of the identity store to assign the role to.
 // to is a list of user ids to assign the role to.
+// A types.ErrInvalidInput is returned, without assigning the role, if ValidateRoleScope rejects the role for the scope.
 func (c *RoleClient) AssignRoleOnDataObject(ctx context.Context, roleId string, doId string, to ...string) (*types.Role, error) {
+	err := c.ValidateRoleScope(ctx, roleId, RoleScopeTypeDataObject)
+	if err != nil {
+		return nil, err
+	}
+
 	output, err := schema.AssignRoleOnDataObject(ctx, c.client, roleId, doId, to)
 	if err != nil {
 		return nil, types.NewErrClient(err)
@@ -335,7 +402,13 @@ func (c *RoleClient) AssignRoleOnDataObject(ctx context.Context, roleId string,
 // roleId is the id of the role to assign.
 // dataSourceId is the id of the data source to assign the role to.
 // to is a list of user ids to assign the role to.
+// A types.ErrInvalidInput is returned, without assigning the role, if ValidateRoleScope rejects the role for the scope.
 func (c *RoleClient) AssignRoleOnDataSource(ctx context.Context, roleId string, dataSourceId string, to ...string) (*types.Role, error) {
+	err := c.ValidateRoleScope(ctx, roleId, RoleScopeTypeDataSource)
+	if err != nil {
+		return nil, err
+	}
+
 	output, err := schema.AssignRoleOnDataSource(ctx, c.client, roleId, dataSourceId, to)
 	if err != nil {
 		return nil, types.NewErrClient(err)
@@ -357,7 +430,13 @@ func (c *RoleClient) AssignRoleOnDataSource(ctx context.Context, roleId string,
 // roleId is the id of the role to assign.
 // accessProviderId is the id of the access provider to assign the role to.
 // to is a list of user ids to assign the role to.
+// A types.ErrInvalidInput is returned, without assigning the role, if ValidateRoleScope rejects the role for the scope.
 func (c *RoleClient) AssignRoleOnAccessProvider(ctx context.Context, roleId string, accessProviderId string, to ...string) (*types.Role, error) {
+	err := c.ValidateRoleScope(ctx, roleId, RoleScopeTypeAccessProvider)
+	if err != nil {
+		return nil, err
+	}
+
 	output, err := schema.AssignRoleOnAccessProvider(ctx, c.client, roleId, accessProviderId, to)
 	if err != nil {
 		return nil, types.NewErrClient(err)
@@ -378,7 +457,13 @@ func (c *RoleClient) AssignRoleOnAccessProvider(ctx context.Context, roleId stri
 // AssignGlobalRole create a role assignment between a global role and a set of users.
 // roleId is the id of the role to assign.
 // to is a list of user ids to assign the role to.
+// A types.ErrInvalidInput is returned, without assigning the role, if ValidateRoleScope rejects the role for the scope.
 func (c *RoleClient) AssignGlobalRole(ctx context.Context, roelId string, to ...string) (*types.Role, error) {
+	err := c.ValidateRoleScope(ctx, roelId, RoleScopeTypeGlobal)
+	if err != nil {
+		return nil, err
+	}
+
 	output, err := schema.AssignGlobalRole(ctx, c.client, roelId, to)
 	if err != nil {
 		return nil, types.NewErrClient(err)
@@ -625,14 +710,367 @@ func (c *RoleClient) SetGlobalRoleForUsers(ctx context.Context, roleId string, a
 	}
 }
 
+// RoleScopeType is the type of resource a role can be assigned on.
+type RoleScopeType string
+
+const (
+	RoleScopeTypeGlobal         RoleScopeType = "Global"
+	RoleScopeTypeIdentityStore  RoleScopeType = "IdentityStore"
+	RoleScopeTypeDataSource     RoleScopeType = "DataSource"
+	RoleScopeTypeDataObject     RoleScopeType = "DataObject"
+	RoleScopeTypeAccessProvider RoleScopeType = "AccessProvider"
+)
+
+// RoleScope identifies the resource a role is assigned on.
+// Id is the id of the resource and should be empty for RoleScopeTypeGlobal.
+type RoleScope struct {
+	Type RoleScopeType
+	Id   string
+}
+
+// GlobalRoleScope returns the scope of global role assignments.
+func GlobalRoleScope() RoleScope {
+	return RoleScope{Type: RoleScopeTypeGlobal}
+}
+
+// IdentityStoreRoleScope returns the scope of role assignments on the identity store with the given id.
+func IdentityStoreRoleScope(id string) RoleScope {
+	return RoleScope{Type: RoleScopeTypeIdentityStore, Id: id}
+}
+
+// DataSourceRoleScope returns the scope of role assignments on the data source with the given id.
+func DataSourceRoleScope(id string) RoleScope {
+	return RoleScope{Type: RoleScopeTypeDataSource, Id: id}
+}
+
+// DataObjectRoleScope returns the scope of role assignments on the data object with the given id.
+func DataObjectRoleScope(id string) RoleScope {
+	return RoleScope{Type: RoleScopeTypeDataObject, Id: id}
+}
+
+// AccessProviderRoleScope returns the scope of role assignments on the access provider with the given id.
+func AccessProviderRoleScope(id string) RoleScope {
+	return RoleScope{Type: RoleScopeTypeAccessProvider, Id: id}
+}
+
+func (s RoleScope) String() string {
+	if s.Type == RoleScopeTypeGlobal {
+		return string(s.Type)
+	}
+
+	return fmt.Sprintf("%s(%s)", s.Type, s.Id)
+}
+
+// ListRoleAssignmentsOn returns a list of role assignments on the given scope.
+// The order of the list can be specified with WithRoleAssignmentListOrder.
+// A filter can be specified with WithRoleAssignmentListFilter.
+// A channel is returned that can be used to receive the list of types.RoleAssignment.
+// To close the channel ensure to cancel the context.
+func (c *RoleClient) ListRoleAssignmentsOn(ctx context.Context, scope RoleScope, ops ...func(*RoleAssignmentListOptions)) <-chan types.ListItem[types.RoleAssignment] {
+	switch scope.Type {
+	case RoleScopeTypeGlobal:
+		options := RoleAssignmentListOptions{}
+		for _, op := range ops {
+			op(&options)
+		}
+
+		filter := types.RoleAssignmentFilterInput{}
+		if options.filter != nil {
+			filter = *options.filter
+		}
+
+		filter.OnlyGlobal = ptr.Bool(true)
+
+		return c.ListRoleAssignments(ctx, WithRoleAssignmentListOrder(options.order...), WithRoleAssignmentListFilter(&filter))
+	case RoleScopeTypeIdentityStore:
+		return c.ListRoleAssignmentsOnIdentityStore(ctx, scope.Id, ops...)
+	case RoleScopeTypeDataSource:
+		return c.ListRoleAssignmentsOnDataSource(ctx, scope.Id, ops...)
+	case RoleScopeTypeDataObject:
+		return c.ListRoleAssignmentsOnDataObject(ctx, scope.Id, ops...)
+	case RoleScopeTypeAccessProvider:
+		return c.ListRoleAssignmentsOnAccessProvider(ctx, scope.Id, ops...)
+	default:
+		ch := make(chan types.ListItem[types.RoleAssignment], 1)
+		ch <- types.NewListItemError[types.RoleAssignment](types.NewErrInvalidInput(fmt.Sprintf("unknown role scope type %q", scope.Type)))
+		close(ch)
+
+		return ch
+	}
+}
+
+// AssignRole create a role assignment between the given scope and a set of users.
+// to is a list of user ids to assign the role to.
+func (c *RoleClient) AssignRole(ctx context.Context, scope RoleScope, roleId string, to ...string) (*types.Role, error) {
+	switch scope.Type {
+	case RoleScopeTypeGlobal:
+		return c.AssignGlobalRole(ctx, roleId, to...)
+	case RoleScopeTypeIdentityStore:
+		return c.AssignRoleOnIdentityStore(ctx, roleId, scope.Id, to...)
+	case RoleScopeTypeDataSource:
+		return c.AssignRoleOnDataSource(ctx, roleId, scope.Id, to...)
+	case RoleScopeTypeDataObject:
+		return c.AssignRoleOnDataObject(ctx, roleId, scope.Id, to...)
+	case RoleScopeTypeAccessProvider:
+		return c.AssignRoleOnAccessProvider(ctx, roleId, scope.Id, to...)
+	default:
+		return nil, types.NewErrInvalidInput(fmt.Sprintf("unknown role scope type %q", scope.Type))
+	}
+}
+
+// UnassignRole removes a role assignment between the given scope and a set of users.
+// from is a list of user ids to unassign the role from.
+func (c *RoleClient) UnassignRole(ctx context.Context, scope RoleScope, roleId string, from ...string) (*types.Role, error) {
+	switch scope.Type {
+	case RoleScopeTypeGlobal:
+		return c.UnassignGlobalRole(ctx, roleId, from...)
+	case RoleScopeTypeIdentityStore:
+		return c.UnassignRoleFromIdentityStore(ctx, roleId, scope.Id, from...)
+	case RoleScopeTypeDataSource:
+		return c.UnassignRoleFromDataSource(ctx, roleId, scope.Id, from...)
+	case RoleScopeTypeDataObject:
+		return c.UnassignRoleFromDataObject(ctx, roleId, scope.Id, from...)
+	case RoleScopeTypeAccessProvider:
+		return c.UnassignRoleFromAccessProvider(ctx, roleId, scope.Id, from...)
+	default:
+		return nil, types.NewErrInvalidInput(fmt.Sprintf("unknown role scope type %q", scope.Type))
+	}
+}
+
+// RoleAssignmentSpec describes the desired assignees of a role on a scope.
+// Assignees is the complete list of user and group ids that should have the role on the scope.
+type RoleAssignmentSpec struct {
+	Scope     RoleScope
+	RoleId    string
+	Assignees []string
+}
+
+// RoleAssignmentChange describes the changes needed to reach the desired assignees of a role on a scope.
+type RoleAssignmentChange struct {
+	Scope    RoleScope
+	RoleId   string
+	Assign   []string
+	Unassign []string
+}
+
+// RoleAssignmentPlan is the set of changes computed by ReconcileRoleAssignments.
+type RoleAssignmentPlan struct {
+	Changes []RoleAssignmentChange
+}
+
+// IsEmpty returns true if the plan contains no changes.
+func (p *RoleAssignmentPlan) IsEmpty() bool {
+	return len(p.Changes) == 0
+}
+
+type ReconcileRoleAssignmentsOptions struct {
+	dryRun         bool
+	validateScopes bool
+}
+
+// WithReconcileRoleAssignmentsDryRun can be used to only compute the plan of ReconcileRoleAssignments without applying it.
+func WithReconcileRoleAssignmentsDryRun() func(options *ReconcileRoleAssignmentsOptions) {
+	return func(options *ReconcileRoleAssignmentsOptions) {
+		options.dryRun = true
+	}
+}
+
+// WithReconcileRoleAssignmentsScopeValidation can be used to check the whole plan of ReconcileRoleAssignments with ValidateRoleScope before it is applied.
+// If a role can not be assigned on its scope, no changes are applied.
+// Without this option, each assignment is still validated by the Assign methods, but the changes before the invalid one are applied.
+func WithReconcileRoleAssignmentsScopeValidation() func(options *ReconcileRoleAssignmentsOptions) {
+	return func(options *ReconcileRoleAssignmentsOptions) {
+		options.validateScopes = true
+	}
+}
+
+// ReconcileRoleAssignments makes the role assignments match the desired specs.
+// For each role and scope in desired, the current assignees are read and the minimal set of
+// assign and unassign calls is computed. Role and scope combinations that are not in desired are left untouched.
+// Multiple specs for the same role and scope are merged.
+// The computed plan is returned. If WithReconcileRoleAssignmentsDryRun is set, the plan is not applied.
+// If applying the plan fails, the error is returned together with the plan.
+func (c *RoleClient) ReconcileRoleAssignments(ctx context.Context, desired []RoleAssignmentSpec, ops ...func(options *ReconcileRoleAssignmentsOptions)) (*RoleAssignmentPlan, error) {
+	options := ReconcileRoleAssignmentsOptions{}
+	for _, op := range ops {
+		op(&options)
+	}
+
+	plan, err := c.planRoleAssignments(ctx, desired)
+	if err != nil {
+		return nil, err
+	}
+
+	if options.validateScopes {
+		err = c.validatePlanScopes(ctx, plan)
+		if err != nil {
+			return plan, err
+		}
+	}
+
+	if options.dryRun {
+		return plan, nil
+	}
+
+	for i := range plan.Changes {
+		change := &plan.Changes[i]
+
+		if len(change.Assign) > 0 {
+			_, err = c.AssignRole(ctx, change.Scope, change.RoleId, change.Assign...)
+			if err != nil {
+				return plan, fmt.Errorf("assign role %q on %s: %w", change.RoleId, change.Scope, err)
+			}
+		}
+
+		if len(change.Unassign) > 0 {
+			_, err = c.UnassignRole(ctx, change.Scope, change.RoleId, change.Unassign...)
+			if err != nil {
+				return plan, fmt.Errorf("unassign role %q on %s: %w", change.RoleId, change.Scope, err)
+			}
+		}
+	}
+
+	return plan, nil
+}
+
+// validatePlanScopes returns a types.ErrInvalidInput if a role in the plan is assigned on a scope it can not be assigned on.
+func (c *RoleClient) validatePlanScopes(ctx context.Context, plan *RoleAssignmentPlan) error {
+	globalRoles, err := c.globalRoleIds(ctx)
+	if err != nil {
+		return err
+	}
+
+	for i := range plan.Changes {
+		if len(plan.Changes[i].Assign) == 0 {
+			continue
+		}
+
+		err = checkRoleScope(globalRoles, plan.Changes[i].RoleId, plan.Changes[i].Scope.Type)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+type roleAssignmentKey struct {
+	scope  RoleScope
+	roleId string
+}
+
+func (c *RoleClient) planRoleAssignments(ctx context.Context, desired []RoleAssignmentSpec) (*RoleAssignmentPlan, error) {
+	desiredAssignees := make(map[roleAssignmentKey]map[string]struct{})
+	keys := make([]roleAssignmentKey, 0, len(desired))
+
+	for i := range desired {
+		key := roleAssignmentKey{scope: desired[i].Scope, roleId: desired[i].RoleId}
+
+		if _, found := desiredAssignees[key]; !found {
+			desiredAssignees[key] = make(map[string]struct{})
+			keys = append(keys, key)
+		}
+
+		for _, assignee := range desired[i].Assignees {
+			desiredAssignees[key][assignee] = struct{}{}
+		}
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].scope.Type != keys[j].scope.Type {
+			return keys[i].scope.Type < keys[j].scope.Type
+		}
+
+		if keys[i].scope.Id != keys[j].scope.Id {
+			return keys[i].scope.Id < keys[j].scope.Id
+		}
+
+		return keys[i].roleId < keys[j].roleId
+	})
+
+	plan := RoleAssignmentPlan{}
+
+	for _, key := range keys {
+		current, err := c.currentRoleAssignees(ctx, key.scope, key.roleId)
+		if err != nil {
+			return nil, fmt.Errorf("load assignees of role %q on %s: %w", key.roleId, key.scope, err)
+		}
+
+		change := RoleAssignmentChange{Scope: key.scope, RoleId: key.roleId}
+
+		for assignee := range desiredAssignees[key] {
+			if _, found := current[assignee]; !found {
+				change.Assign = append(change.Assign, assignee)
+			}
+		}
+
+		for assignee := range current {
+			if _, found := desiredAssignees[key][assignee]; !found {
+				change.Unassign = append(change.Unassign, assignee)
+			}
+		}
+
+		if len(change.Assign) == 0 && len(change.Unassign) == 0 {
+			continue
+		}
+
+		sort.Strings(change.Assign)
+		sort.Strings(change.Unassign)
+
+		plan.Changes = append(plan.Changes, change)
+	}
+
+	return &plan, nil
+}
+
+// currentRoleAssignees returns the direct assignees of a role on a scope. Inherited assignments are ignored as they can not be unassigned on the scope.
+func (c *RoleClient) currentRoleAssignees(ctx context.Context, scope RoleScope, roleId string) (map[string]struct{}, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	assignees, err := roleAssigneeIds(c.ListRoleAssignmentsOn(ctx, scope, WithRoleAssignmentListFilter(&types.RoleAssignmentFilterInput{Role: ptr.String(roleId), Inherited: ptr.Bool(false)})))
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]struct{}, len(assignees))
+	for _, assignee := range assignees {
+		result[assignee] = struct{}{}
+	}
+
+	return result, nil
+}
+
 func roleAssignmentsEdgeFn(edge *types.RoleAssignmentPageEdgesEdge) (*string, *schema.RoleAssignment, error) {
-	cursor := edge.Cursor
+	return internal.DecodeEdgeNode(edge.Cursor, edge.Node, func(node *types.RoleAssignmentPageEdgesEdgeNodeRoleAssignment) *schema.RoleAssignment {
+		return &node.RoleAssignment
+	})
+}
+
+// roleAssigneeIds returns the ids of the users and groups of all role assignments received on the channel.
+// Assignments without a user or group assignee, e.g. because the assignee is not visible to the caller, are skipped.
+func roleAssigneeIds(ch <-chan types.ListItem[types.RoleAssignment]) ([]string, error) {
+	assignments, err := internal.CollectListItems(ch)
+	if err != nil {
+		return nil, err
+	}
+
+	assignees := make([]string, 0, len(assignments))
 
-	if edge.Node == nil {
-		return cursor, nil, nil
+	for i := range assignments {
+		switch to := assignments[i].To.(type) {
+		case *types.RoleAssignmentToUser:
+			assignees = append(assignees, to.Id)
+		case *types.RoleAssignmentToGroup:
+			assignees = append(assignees, to.Id)
+		}
 	}
 
-	listItem := (*edge.Node).(*types.RoleAssignmentPageEdgesEdgeNodeRoleAssignment)
+	return assignees, nil
+}
 
-	return cursor, &listItem.RoleAssignment, nil
+func rolesEdgeFn(edge *types.RolePageEdgesEdge) (*string, *schema.Role, error) {
+	return internal.DecodeEdgeNode(edge.Cursor, edge.Node, func(node *types.RolePageEdgesEdgeNodeRole) *schema.Role {
+		return &node.Role
+	})
 }
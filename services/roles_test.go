@@ -0,0 +1,253 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/raito-io/sdk-go/types"
+)
+
+// newFakeRoleAssignmentClient returns a client that lists the given direct assignees per scope and role.
+// Assignees starting with "g" are returned as groups, others as users.
+func newFakeRoleAssignmentClient(current map[RoleScope]map[string][]string) *fakeGraphqlClient {
+	client := newFakeGraphqlClient()
+
+	page := func(scope RoleScope, variables map[string]any) string {
+		filter, _ := variables["filter"].(map[string]any)
+		roleId, _ := filter["role"].(string)
+
+		nodes := make([]string, 0, len(current[scope][roleId]))
+
+		for _, assignee := range current[scope][roleId] {
+			typename := "User"
+			if strings.HasPrefix(assignee, "g") {
+				typename = "Group"
+			}
+
+			nodes = append(nodes, fmt.Sprintf(`{"__typename":"RoleAssignment","id":"%s-%s","to":{"__typename":"%s","id":"%s"}}`, roleId, assignee, typename, assignee))
+		}
+
+		return pagedResult(nodes...)
+	}
+
+	client.handle("ListRoleAssignments", func(variables map[string]any) string {
+		return fmt.Sprintf(`{"roleAssignments":%s}`, page(GlobalRoleScope(), variables))
+	})
+	client.handle("ListRoleAssignmentsOnIdentityStore", func(variables map[string]any) string {
+		return fmt.Sprintf(`{"identityStore":{"__typename":"IdentityStore","roleAssignments":%s}}`, page(IdentityStoreRoleScope(variables["isId"].(string)), variables))
+	})
+	client.handle("ListRoleAssignmentsOnDataSource", func(variables map[string]any) string {
+		return fmt.Sprintf(`{"dataSource":{"__typename":"DataSource","roleAssignments":%s}}`, page(DataSourceRoleScope(variables["dsId"].(string)), variables))
+	})
+	client.handle("ListRoleAssignmentsOnDataObject", func(variables map[string]any) string {
+		return fmt.Sprintf(`{"dataObject":{"roleAssignments":%s}}`, page(DataObjectRoleScope(variables["doId"].(string)), variables))
+	})
+	client.handle("ListRoleAssignmentsOnAccessProvider", func(variables map[string]any) string {
+		return fmt.Sprintf(`{"accessProvider":{"__typename":"AccessProvider","roleAssignments":%s}}`, page(AccessProviderRoleScope(variables["apId"].(string)), variables))
+	})
+
+	return client
+}
+
+func TestRoleClient_PlanRoleAssignments(t *testing.T) {
+	tests := []struct {
+		name     string
+		current  map[RoleScope]map[string][]string
+		desired  []RoleAssignmentSpec
+		expected []RoleAssignmentChange
+	}{
+		{
+			name: "add only",
+			current: map[RoleScope]map[string][]string{
+				DataSourceRoleScope("ds1"): {"role1": {"u1"}},
+			},
+			desired: []RoleAssignmentSpec{
+				{Scope: DataSourceRoleScope("ds1"), RoleId: "role1", Assignees: []string{"u3", "u1", "g1"}},
+			},
+			expected: []RoleAssignmentChange{
+				{Scope: DataSourceRoleScope("ds1"), RoleId: "role1", Assign: []string{"g1", "u3"}},
+			},
+		},
+		{
+			name: "remove only",
+			current: map[RoleScope]map[string][]string{
+				GlobalRoleScope(): {"admin": {"u1", "u2", "g1"}},
+			},
+			desired: []RoleAssignmentSpec{
+				{Scope: GlobalRoleScope(), RoleId: "admin", Assignees: []string{"u2"}},
+			},
+			expected: []RoleAssignmentChange{
+				{Scope: GlobalRoleScope(), RoleId: "admin", Unassign: []string{"g1", "u1"}},
+			},
+		},
+		{
+			name: "remove all assignees",
+			current: map[RoleScope]map[string][]string{
+				AccessProviderRoleScope("ap1"): {"owner": {"u1"}},
+			},
+			desired: []RoleAssignmentSpec{
+				{Scope: AccessProviderRoleScope("ap1"), RoleId: "owner"},
+			},
+			expected: []RoleAssignmentChange{
+				{Scope: AccessProviderRoleScope("ap1"), RoleId: "owner", Unassign: []string{"u1"}},
+			},
+		},
+		{
+			name: "no-op",
+			current: map[RoleScope]map[string][]string{
+				DataObjectRoleScope("do1"): {"owner": {"u1", "g1"}},
+			},
+			desired: []RoleAssignmentSpec{
+				{Scope: DataObjectRoleScope("do1"), RoleId: "owner", Assignees: []string{"g1", "u1"}},
+			},
+		},
+		{
+			name: "mixed scopes",
+			current: map[RoleScope]map[string][]string{
+				GlobalRoleScope():              {"admin": {"u1"}},
+				IdentityStoreRoleScope("is1"):  {"owner": {"u1", "u2"}},
+				DataSourceRoleScope("ds1"):     {"owner": {"u1"}, "viewer": {"u9"}},
+				DataObjectRoleScope("do1"):     {"owner": {"u1"}},
+				AccessProviderRoleScope("ap1"): {"owner": {"u5"}},
+			},
+			desired: []RoleAssignmentSpec{
+				{Scope: AccessProviderRoleScope("ap1"), RoleId: "owner", Assignees: []string{"u6"}},
+				{Scope: DataSourceRoleScope("ds1"), RoleId: "owner", Assignees: []string{"u1"}},
+				{Scope: DataSourceRoleScope("ds1"), RoleId: "owner", Assignees: []string{"u2"}},
+				{Scope: IdentityStoreRoleScope("is1"), RoleId: "owner", Assignees: []string{"u2"}},
+				{Scope: DataObjectRoleScope("do1"), RoleId: "owner", Assignees: []string{"u1"}},
+				{Scope: GlobalRoleScope(), RoleId: "admin", Assignees: []string{"u1", "u2"}},
+			},
+			expected: []RoleAssignmentChange{
+				{Scope: AccessProviderRoleScope("ap1"), RoleId: "owner", Assign: []string{"u6"}, Unassign: []string{"u5"}},
+				{Scope: DataSourceRoleScope("ds1"), RoleId: "owner", Assign: []string{"u2"}},
+				{Scope: GlobalRoleScope(), RoleId: "admin", Assign: []string{"u2"}},
+				{Scope: IdentityStoreRoleScope("is1"), RoleId: "owner", Unassign: []string{"u1"}},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			roleClient := NewRoleClient(newFakeRoleAssignmentClient(test.current))
+
+			plan, err := roleClient.planRoleAssignments(context.Background(), test.desired)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, plan.Changes)
+			assert.Equal(t, len(test.expected) == 0, plan.IsEmpty())
+		})
+	}
+}
+
+func TestRoleClient_ReconcileRoleAssignments_DryRun(t *testing.T) {
+	client := newFakeRoleAssignmentClient(map[RoleScope]map[string][]string{
+		DataSourceRoleScope("ds1"): {"owner": {"u1"}},
+	})
+
+	roleClient := NewRoleClient(client)
+
+	plan, err := roleClient.ReconcileRoleAssignments(context.Background(), []RoleAssignmentSpec{
+		{Scope: DataSourceRoleScope("ds1"), RoleId: "owner", Assignees: []string{"u2"}},
+	}, WithReconcileRoleAssignmentsDryRun())
+	require.NoError(t, err)
+
+	assert.Equal(t, []RoleAssignmentChange{
+		{Scope: DataSourceRoleScope("ds1"), RoleId: "owner", Assign: []string{"u2"}, Unassign: []string{"u1"}},
+	}, plan.Changes)
+	assert.Equal(t, 1, client.calls("ListRoleAssignmentsOnDataSource"))
+}
+
+func handleListRoles(client *fakeGraphqlClient) {
+	client.handle("ListRoles", func(variables map[string]any) string {
+		filter, _ := variables["filter"].(map[string]any)
+
+		admin := `{"__typename":"Role","id":"Admin","name":"Admin"}`
+		owner := `{"__typename":"Role","id":"owner","name":"Owner"}`
+
+		switch filter["isGlobal"] {
+		case true:
+			return `{"roles":` + pagedResult(admin) + `}`
+		case false:
+			return `{"roles":` + pagedResult(owner) + `}`
+		default:
+			return `{"roles":` + pagedResult(admin, owner) + `}`
+		}
+	})
+}
+
+func TestRoleClient_ValidateRoleScope(t *testing.T) {
+	client := newFakeGraphqlClient()
+	handleListRoles(client)
+
+	roleClient := NewRoleClient(client)
+
+	require.NoError(t, roleClient.ValidateRoleScope(context.Background(), "Admin", RoleScopeTypeGlobal))
+	require.NoError(t, roleClient.ValidateRoleScope(context.Background(), "owner", RoleScopeTypeDataSource))
+
+	var invalidInput *types.ErrInvalidInput
+
+	require.ErrorAs(t, roleClient.ValidateRoleScope(context.Background(), "Admin", RoleScopeTypeDataObject), &invalidInput)
+	require.ErrorAs(t, roleClient.ValidateRoleScope(context.Background(), "owner", RoleScopeTypeGlobal), &invalidInput)
+}
+
+func TestRoleClient_AssignRole_ScopeValidation(t *testing.T) {
+	client := newFakeGraphqlClient()
+	handleListRoles(client)
+
+	client.handle("AssignRoleOnDataObject", func(map[string]any) string {
+		return `{"assignRoleOnDataObject":{"__typename":"Role","id":"owner","name":"Owner"}}`
+	})
+	client.handle("AssignGlobalRole", func(map[string]any) string {
+		return `{"assignGlobalRole":{"__typename":"Role","id":"Admin","name":"Admin"}}`
+	})
+
+	ctx := context.Background()
+	roleClient := NewRoleClient(client)
+
+	var invalidInput *types.ErrInvalidInput
+
+	_, err := roleClient.AssignRoleOnDataObject(ctx, "Admin", "do1", "u1")
+	require.ErrorAs(t, err, &invalidInput)
+	assert.Equal(t, 0, client.calls("AssignRoleOnDataObject"))
+
+	_, err = roleClient.AssignRole(ctx, GlobalRoleScope(), "owner", "u1")
+	require.ErrorAs(t, err, &invalidInput)
+	assert.Equal(t, 0, client.calls("AssignGlobalRole"))
+
+	role, err := roleClient.AssignRoleOnDataObject(ctx, "owner", "do1", "u1")
+	require.NoError(t, err)
+	assert.Equal(t, "owner", role.Id)
+
+	_, err = roleClient.AssignGlobalRole(ctx, "Admin", "u1")
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, client.calls("AssignRoleOnDataObject"))
+	assert.Equal(t, 1, client.calls("AssignGlobalRole"))
+}
+
+func TestRoleClient_ReconcileRoleAssignments_ScopeValidation(t *testing.T) {
+	client := newFakeRoleAssignmentClient(map[RoleScope]map[string][]string{})
+	handleListRoles(client)
+
+	roleClient := NewRoleClient(client)
+
+	desired := []RoleAssignmentSpec{
+		{Scope: DataSourceRoleScope("ds1"), RoleId: "Admin", Assignees: []string{"u1"}},
+	}
+
+	_, err := roleClient.ReconcileRoleAssignments(context.Background(), desired, WithReconcileRoleAssignmentsDryRun())
+	require.NoError(t, err)
+	assert.Equal(t, 0, client.calls("ListRoles"), "scopes are only validated on request")
+
+	var invalidInput *types.ErrInvalidInput
+
+	_, err = roleClient.ReconcileRoleAssignments(context.Background(), desired, WithReconcileRoleAssignmentsScopeValidation())
+	require.ErrorAs(t, err, &invalidInput)
+	assert.Equal(t, 0, client.calls("AssignRoleOnDataSource"))
+}
@@ -0,0 +1,110 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/raito-io/sdk-go/types"
+)
+
+// checkUnmodifiedSince returns a types.ErrConflict if modifiedAt is after the expected modification time.
+// If no expected modification time is set, nil is returned.
+// The API has no conditional updates, so callers check a freshly read modifiedAt before writing.
+// This narrows the window for lost updates, but a write between the read and the update is not detected.
+func checkUnmodifiedSince(objectType string, id string, modifiedAt time.Time, unmodifiedSince *time.Time) error {
+	if unmodifiedSince == nil || !modifiedAt.After(*unmodifiedSince) {
+		return nil
+	}
+
+	return types.NewErrConflict(objectType, id, *unmodifiedSince, modifiedAt)
+}
+
+type loadCacheEntry[T any] struct {
+	once  sync.Once
+	value T
+	err   error
+}
+
+// loadCache caches the result of a load function per key.
+// Concurrent calls for the same key wait for a single load.
+type loadCache[T any] struct {
+	mutex   sync.Mutex
+	entries map[string]*loadCacheEntry[T]
+}
+
+func (c *loadCache[T]) get(key string, load func() (T, error)) (T, error) {
+	c.mutex.Lock()
+
+	if c.entries == nil {
+		c.entries = make(map[string]*loadCacheEntry[T])
+	}
+
+	entry, found := c.entries[key]
+	if !found {
+		entry = &loadCacheEntry[T]{}
+		c.entries[key] = entry
+	}
+
+	c.mutex.Unlock()
+
+	entry.once.Do(func() {
+		entry.value, entry.err = load()
+	})
+
+	return entry.value, entry.err
+}
+
+// forEachConcurrent calls fn for every item with at most limit concurrent calls.
+// The first error is returned and cancels the context passed to the remaining calls.
+func forEachConcurrent[T any](ctx context.Context, items []T, limit int, fn func(ctx context.Context, item T) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	if limit < 1 {
+		limit = 1
+	}
+
+	var wg sync.WaitGroup
+	var errOnce sync.Once
+	var firstErr error
+
+	semaphore := make(chan struct{}, limit)
+
+	for i := range items {
+		select {
+		case semaphore <- struct{}{}:
+		case <-ctx.Done():
+		}
+
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+
+		go func(item T) {
+			defer func() {
+				<-semaphore
+				wg.Done()
+			}()
+
+			err := fn(ctx, item)
+			if err != nil {
+				errOnce.Do(func() {
+					firstErr = err
+
+					cancel()
+				})
+			}
+		}(items[i])
+	}
+
+	wg.Wait()
+
+	if firstErr != nil {
+		return firstErr
+	}
+
+	return ctx.Err()
+}
@@ -3,6 +3,8 @@ package types
 import (
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 )
 
 var ErrUnknownType = errors.New("unknown type")
@@ -106,3 +108,76 @@ func NewErrClient(clientErr error) *ErrClient {
 func (e *ErrClient) Error() string {
 	return fmt.Sprintf("client error: %s", e.clientErr)
 }
+
+type ErrConflict struct {
+	Type               string
+	Id                 string
+	ExpectedModifiedAt time.Time
+	ActualModifiedAt   time.Time
+}
+
+func NewErrConflict(t string, id string, expectedModifiedAt time.Time, actualModifiedAt time.Time) *ErrConflict {
+	return &ErrConflict{
+		Type:               t,
+		Id:                 id,
+		ExpectedModifiedAt: expectedModifiedAt,
+		ActualModifiedAt:   actualModifiedAt,
+	}
+}
+
+func (e *ErrConflict) Error() string {
+	return fmt.Sprintf("%q with id %q was modified at %s, after %s", e.Type, e.Id, e.ActualModifiedAt.Format(time.RFC3339Nano), e.ExpectedModifiedAt.Format(time.RFC3339Nano))
+}
+
+type ErrUnexpectedNodeType struct {
+	Typename     *string
+	NodeType     string
+	ExpectedType string
+}
+
+func NewErrUnexpectedNodeType(typename *string, nodeType string, expectedType string) *ErrUnexpectedNodeType {
+	return &ErrUnexpectedNodeType{
+		Typename:     typename,
+		NodeType:     nodeType,
+		ExpectedType: expectedType,
+	}
+}
+
+func (e *ErrUnexpectedNodeType) Error() string {
+	t := "<unknown>"
+
+	if e.Typename != nil {
+		t = *e.Typename
+	}
+
+	return fmt.Sprintf("unexpected node %q of type %s, expected %s", t, e.NodeType, e.ExpectedType)
+}
+
+func (e *ErrUnexpectedNodeType) Unwrap() error {
+	return ErrUnknownType
+}
+
+type ValidationViolation struct {
+	Field   string
+	Message string
+}
+
+type ErrValidation struct {
+	Violations []ValidationViolation
+}
+
+func NewErrValidation(violations []ValidationViolation) *ErrValidation {
+	return &ErrValidation{
+		Violations: violations,
+	}
+}
+
+func (e *ErrValidation) Error() string {
+	msgs := make([]string, 0, len(e.Violations))
+
+	for _, violation := range e.Violations {
+		msgs = append(msgs, fmt.Sprintf("%s: %s", violation.Field, violation.Message))
+	}
+
+	return fmt.Sprintf("validation failed: %s", strings.Join(msgs, "; "))
+}
@@ -707,6 +707,13 @@ type GetAccessProviderAccessProviderInvalidInputError = schema.GetAccessProvider
 type GetAccessProviderAccessProviderNotFoundError = schema.GetAccessProviderAccessProviderNotFoundError
 type GetAccessProviderAccessProviderPermissionDeniedError = schema.GetAccessProviderAccessProviderPermissionDeniedError
 type GetAccessProviderResponse = schema.GetAccessProviderResponse
+type GetAccessProviderTagsAccessProvider = schema.GetAccessProviderTagsAccessProvider
+type GetAccessProviderTagsAccessProviderAccessProviderResult = schema.GetAccessProviderTagsAccessProviderAccessProviderResult
+type GetAccessProviderTagsAccessProviderInvalidInputError = schema.GetAccessProviderTagsAccessProviderInvalidInputError
+type GetAccessProviderTagsAccessProviderNotFoundError = schema.GetAccessProviderTagsAccessProviderNotFoundError
+type GetAccessProviderTagsAccessProviderPermissionDeniedError = schema.GetAccessProviderTagsAccessProviderPermissionDeniedError
+type GetAccessProviderTagsAccessProviderTagsTag = schema.GetAccessProviderTagsAccessProviderTagsTag
+type GetAccessProviderTagsResponse = schema.GetAccessProviderTagsResponse
 type GetAccessProviderWhatAccessProvidersAccessProvider = schema.GetAccessProviderWhatAccessProvidersAccessProvider
 type GetAccessProviderWhatAccessProvidersAccessProviderAccessProviderResult = schema.GetAccessProviderWhatAccessProvidersAccessProviderAccessProviderResult
 type GetAccessProviderWhatAccessProvidersAccessProviderInvalidInputError = schema.GetAccessProviderWhatAccessProvidersAccessProviderInvalidInputError
@@ -742,6 +749,17 @@ type GetAccessProviderWhoListAccessProviderWhoListPermissionDeniedError = schema
 type GetAccessProviderWhoListResponse = schema.GetAccessProviderWhoListResponse
 type GetDataObjectDataObject = schema.GetDataObjectDataObject
 type GetDataObjectResponse = schema.GetDataObjectResponse
+type GetDataObjectTagsDataObject = schema.GetDataObjectTagsDataObject
+type GetDataObjectTagsDataObjectTagsTag = schema.GetDataObjectTagsDataObjectTagsTag
+type GetDataObjectTagsResponse = schema.GetDataObjectTagsResponse
+type GetDataSourceCapabilitiesDataSource = schema.GetDataSourceCapabilitiesDataSource
+type GetDataSourceCapabilitiesDataSourceAccessProviderTypesAccessProviderType = schema.GetDataSourceCapabilitiesDataSourceAccessProviderTypesAccessProviderType
+type GetDataSourceCapabilitiesDataSourceDataSourceResult = schema.GetDataSourceCapabilitiesDataSourceDataSourceResult
+type GetDataSourceCapabilitiesDataSourceInvalidInputError = schema.GetDataSourceCapabilitiesDataSourceInvalidInputError
+type GetDataSourceCapabilitiesDataSourceMaskingMetadata = schema.GetDataSourceCapabilitiesDataSourceMaskingMetadata
+type GetDataSourceCapabilitiesDataSourceNotFoundError = schema.GetDataSourceCapabilitiesDataSourceNotFoundError
+type GetDataSourceCapabilitiesDataSourcePermissionDeniedError = schema.GetDataSourceCapabilitiesDataSourcePermissionDeniedError
+type GetDataSourceCapabilitiesResponse = schema.GetDataSourceCapabilitiesResponse
 type GetDataSourceDataSource = schema.GetDataSourceDataSource
 type GetDataSourceDataSourceDataSourceResult = schema.GetDataSourceDataSourceDataSourceResult
 type GetDataSourceDataSourceInvalidInputError = schema.GetDataSourceDataSourceInvalidInputError
@@ -988,6 +1006,12 @@ type ListRolesRolesPagedResult = schema.ListRolesRolesPagedResult
 type MaskType = schema.MaskType
 type MaskingMetadata = schema.MaskingMetadata
 type MaskingMetadataMaskTypesMaskType = schema.MaskingMetadataMaskTypesMaskType
+type MoveDataSourceResponse = schema.MoveDataSourceResponse
+type MoveDataSourceUpdateDataSource = schema.MoveDataSourceUpdateDataSource
+type MoveDataSourceUpdateDataSourceDataSourceResult = schema.MoveDataSourceUpdateDataSourceDataSourceResult
+type MoveDataSourceUpdateDataSourceInvalidInputError = schema.MoveDataSourceUpdateDataSourceInvalidInputError
+type MoveDataSourceUpdateDataSourceNotFoundError = schema.MoveDataSourceUpdateDataSourceNotFoundError
+type MoveDataSourceUpdateDataSourcePermissionDeniedError = schema.MoveDataSourceUpdateDataSourcePermissionDeniedError
 type NotFoundError = schema.NotFoundError
 type PageInfo = schema.PageInfo
 type PermissionDeniedError = schema.PermissionDeniedError
@@ -1163,6 +1187,7 @@ const (
 	SyncStatusSynced       SyncStatus = schema.SyncStatusSynced
 )
 
+type Tag = schema.Tag
 type TagFilter = schema.TagFilter
 type UnassignGlobalRoleResponse = schema.UnassignGlobalRoleResponse
 type UnassignGlobalRoleUnassignGlobalRole = schema.UnassignGlobalRoleUnassignGlobalRole